@@ -0,0 +1,59 @@
+package proof
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff is an exponential backoff policy. The same type drives HTTP retry
+// delays (WithBackoff) and, when set on WaitOptions, polling intervals.
+type Backoff struct {
+	Base   time.Duration // delay for attempt 0
+	Factor float64       // growth per attempt; values below 1 mean a constant delay
+	Max    time.Duration // upper bound on any delay; zero means uncapped
+	Jitter float64       // fraction in [0, 1] of each delay that is randomized away
+}
+
+// DefaultBackoff is the retry policy used when WithBackoff is not supplied:
+// 1s, 2s, 4s, 8s, then capped at 10s, without jitter.
+var DefaultBackoff = Backoff{Base: time.Second, Factor: 2, Max: 10 * time.Second}
+
+// Delay returns the wait before the given zero-based attempt. With jitter j the
+// result lies in [d*(1-j), d], where d is the capped exponential delay. An
+// uncapped delay saturates at the largest time.Duration instead of overflowing.
+func (b Backoff) Delay(attempt int) time.Duration {
+	factor := b.Factor
+	if factor < 1 {
+		factor = 1
+	}
+	d := float64(b.Base) * math.Pow(factor, float64(attempt))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	// float64(math.MaxInt64) is 2^63, one past the largest Duration.
+	if d >= float64(math.MaxInt64) {
+		d = float64(math.MaxInt64)
+	}
+	if b.Jitter > 0 {
+		d -= d * math.Min(b.Jitter, 1) * rand.Float64()
+	}
+	if d >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// WithBackoff sets the backoff policy for HTTP retries. Zero Base and Factor
+// fall back to DefaultBackoff's values.
+func WithBackoff(b Backoff) ClientOption {
+	return func(c *clientConfig) {
+		if b.Base <= 0 {
+			b.Base = DefaultBackoff.Base
+		}
+		if b.Factor == 0 {
+			b.Factor = DefaultBackoff.Factor
+		}
+		c.backoff = b
+	}
+}
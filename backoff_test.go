@@ -0,0 +1,108 @@
+package proof
+
+import (
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff_ExponentialWithCap(t *testing.T) {
+	b := Backoff{Base: 100 * time.Millisecond, Factor: 3, Max: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second, time.Second}
+	for attempt, w := range want {
+		if got := b.Delay(attempt); got != w {
+			t.Errorf("Delay(%d): want %v, got %v", attempt, w, got)
+		}
+	}
+}
+
+func TestBackoff_Uncapped(t *testing.T) {
+	b := Backoff{Base: time.Second, Factor: 2}
+	if got := b.Delay(5); got != 32*time.Second {
+		t.Errorf("want 32s, got %v", got)
+	}
+}
+
+func TestBackoff_UncappedSaturates(t *testing.T) {
+	for _, b := range []Backoff{
+		{Base: time.Second, Factor: 2},
+		{Base: time.Second, Factor: 2, Jitter: 0.5},
+	} {
+		for _, attempt := range []int{40, 100, 2000} {
+			if got := b.Delay(attempt); got <= 0 {
+				t.Errorf("%+v Delay(%d): want a positive saturated delay, got %v", b, attempt, got)
+			}
+		}
+		if got := b.Delay(2000); b.Jitter == 0 && got != time.Duration(math.MaxInt64) {
+			t.Errorf("Delay(2000): want the largest Duration, got %v", got)
+		}
+	}
+}
+
+func TestBackoff_ConstantWhenFactorBelowOne(t *testing.T) {
+	b := Backoff{Base: 500 * time.Millisecond}
+	for attempt := 0; attempt < 3; attempt++ {
+		if got := b.Delay(attempt); got != 500*time.Millisecond {
+			t.Errorf("Delay(%d): want 500ms, got %v", attempt, got)
+		}
+	}
+}
+
+func TestBackoff_JitterBounds(t *testing.T) {
+	b := Backoff{Base: time.Second, Factor: 2, Max: 4 * time.Second, Jitter: 0.5}
+	for i := 0; i < 200; i++ {
+		for attempt, full := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+			got := b.Delay(attempt)
+			if got < full/2 || got > full {
+				t.Fatalf("Delay(%d) = %v, want within [%v, %v]", attempt, got, full/2, full)
+			}
+		}
+	}
+}
+
+func TestBackoff_JitterClampedToOne(t *testing.T) {
+	b := Backoff{Base: time.Second, Jitter: 5}
+	for i := 0; i < 100; i++ {
+		if got := b.Delay(0); got < 0 || got > time.Second {
+			t.Fatalf("Delay(0) = %v, want within [0, 1s]", got)
+		}
+	}
+}
+
+func TestWithBackoff_FillsDefaults(t *testing.T) {
+	cfg := &clientConfig{}
+	WithBackoff(Backoff{Max: 3 * time.Second})(cfg)
+	if cfg.backoff.Base != DefaultBackoff.Base || cfg.backoff.Factor != DefaultBackoff.Factor {
+		t.Errorf("want default base/factor, got %+v", cfg.backoff)
+	}
+	if cfg.backoff.Max != 3*time.Second {
+		t.Errorf("want max 3s, got %v", cfg.backoff.Max)
+	}
+}
+
+func TestRetryDelay_RateLimitWithoutRetryAfterIsJittered(t *testing.T) {
+	h := newHTTPClient("pk_test_123", "http://example.invalid", time.Second, 3)
+	h.retryBackoff = Backoff{Base: 100 * time.Millisecond, Factor: 2}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 50; i++ {
+		d := h.retryDelay(1, resp)
+		if d < 100*time.Millisecond || d > 200*time.Millisecond {
+			t.Fatalf("delay %s outside [100ms, 200ms]", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Error("want jittered delays, got the same value every time")
+	}
+
+	if d := h.retryDelay(20, resp); d > maxRateLimitDelay {
+		t.Errorf("delay %s exceeds cap %s", d, maxRateLimitDelay)
+	}
+	// Other retryable statuses keep the deterministic backoff.
+	if d := h.retryDelay(1, &http.Response{StatusCode: 503}); d != 200*time.Millisecond {
+		t.Errorf("want unjittered 200ms for 503, got %s", d)
+	}
+}
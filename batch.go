@@ -0,0 +1,84 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultBatchConcurrency bounds how many requests a batch helper has in flight.
+const defaultBatchConcurrency = 5
+
+// BatchResult is the outcome of one item in a batch helper such as
+// Proofs.RevokeBatch.
+type BatchResult struct {
+	ID     string
+	Result map[string]any
+	Err    error
+	// Skipped is true when the context was done before this item started; Err
+	// is then the context's error.
+	Skipped bool
+}
+
+// runBatch calls fn for every id with at most concurrency calls in flight and
+// returns one result per id, in input order. One failure does not stop the
+// others.
+//
+// Once ctx is done no new calls are started: items already finished keep
+// their results, the rest are marked Skipped, and the returned error includes
+// ctx.Err() so callers can salvage partial work and still detect the
+// cancellation with errors.Is. Otherwise the error joins every per-id error,
+// or is nil.
+func runBatch(
+	ctx context.Context,
+	ids []string,
+	concurrency int,
+	fn func(ctx context.Context, id string) (map[string]any, error),
+) ([]BatchResult, error) {
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	results := make([]BatchResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	skipFrom := len(ids)
+	for i, id := range ids {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		// Re-check after acquiring a slot: both cases may have been ready.
+		if ctx.Err() != nil {
+			skipFrom = i
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Result, results[i].Err = fn(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results[:skipFrom] {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.ID, r.Err))
+		}
+	}
+	if skipFrom < len(ids) {
+		for i := skipFrom; i < len(ids); i++ {
+			results[i].Err = ctx.Err()
+			results[i].Skipped = true
+		}
+		errs = append(errs, fmt.Errorf("batch stopped with %d of %d items not started: %w", len(ids)-skipFrom, len(ids), ctx.Err()))
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatch_BoundedConcurrencyAndOrder(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	ids := []string{"a", "b", "c", "d", "e", "f", "g"}
+	results, err := runBatch(context.Background(), ids, 2, func(ctx context.Context, id string) (map[string]any, error) {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return map[string]any{"id": id}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("want at most 2 in flight, got %d", maxInFlight.Load())
+	}
+	for i, r := range results {
+		if r.ID != ids[i] || r.Result["id"] != ids[i] {
+			t.Errorf("result %d: want id %q, got %+v", i, ids[i], r)
+		}
+	}
+}
+
+func TestRunBatch_CancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	results, err := runBatch(ctx, []string{"a", "b"}, 1, func(ctx context.Context, id string) (map[string]any, error) {
+		calls.Add(1)
+		return nil, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("want no calls, got %d", calls.Load())
+	}
+	for _, r := range results {
+		if !r.Skipped || !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %q: want skipped with context.Canceled, got %+v", r.ID, r)
+		}
+	}
+}
+
+func TestRunBatch_PartialResultsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ids := []string{"a", "b", "c", "d"}
+	results, err := runBatch(ctx, ids, 1, func(ctx context.Context, id string) (map[string]any, error) {
+		if id == "b" {
+			cancel() // operator aborts while "b" is in flight
+		}
+		return map[string]any{"id": id}, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled in error, got %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("want %d results, got %d", len(ids), len(results))
+	}
+	for _, r := range results[:2] {
+		if r.Skipped || r.Err != nil || r.Result["id"] != r.ID {
+			t.Errorf("completed result %q should be kept, got %+v", r.ID, r)
+		}
+	}
+	for _, r := range results[2:] {
+		if !r.Skipped || !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %q: want skipped, got %+v", r.ID, r)
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package proof
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -15,6 +21,59 @@ const (
 type WaitOptions struct {
 	Interval time.Duration
 	Timeout  time.Duration
+	// Backoff, when set, replaces the fixed Interval with exponentially growing
+	// delays between polls (Backoff.Delay(0) after the first poll, and so on).
+	Backoff *Backoff
+	// PreferStream makes Verifications.WaitForCompletion follow the status
+	// stream (see Verifications.Stream) instead of polling. If the stream is
+	// unavailable or drops before a terminal status, waiting continues by
+	// polling within the same overall Timeout. Off by default.
+	PreferStream bool
+	// DisableStream forces polling for the call even when PreferStream is set,
+	// e.g. by the client's WithDefaultWaitOptions.
+	DisableStream bool
+	// InitialDelay, when set, waits before the first retrieve, e.g. for a
+	// resource that was just created and is known to be pending. It counts
+	// toward Timeout. By default the first retrieve happens immediately.
+	InitialDelay time.Duration
+	// OnPoll, when set, is called with the zero-based poll number and the
+	// retrieved resource after every poll, e.g. for progress reporting.
+	OnPoll func(poll int, resource map[string]any)
+	// MaxPolls, when positive, ends waiting with a *PollingTimeoutError after
+	// that many polls even if Timeout has not elapsed.
+	MaxPolls int
+}
+
+// WaitOption configures a WaitForCompletionOpts call.
+type WaitOption func(*WaitOptions)
+
+// WithInterval sets the delay between polls.
+func WithInterval(d time.Duration) WaitOption {
+	return func(o *WaitOptions) { o.Interval = d }
+}
+
+// WithWaitTimeout sets the overall time to wait.
+func WithWaitTimeout(d time.Duration) WaitOption {
+	return func(o *WaitOptions) { o.Timeout = d }
+}
+
+// WithOnPoll sets a callback invoked after every poll.
+func WithOnPoll(fn func(poll int, resource map[string]any)) WaitOption {
+	return func(o *WaitOptions) { o.OnPoll = fn }
+}
+
+// WithMaxPolls limits the number of polls.
+func WithMaxPolls(n int) WaitOption {
+	return func(o *WaitOptions) { o.MaxPolls = n }
+}
+
+// buildWaitOptions applies opts in order; later options win.
+func buildWaitOptions(opts []WaitOption) *WaitOptions {
+	var o WaitOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
 }
 
 func resolveWaitOptions(opts *WaitOptions) (interval, timeout time.Duration) {
@@ -28,26 +87,140 @@ func resolveWaitOptions(opts *WaitOptions) (interval, timeout time.Duration) {
 			timeout = opts.Timeout
 		}
 	}
+	// A short explicit Timeout with the default Interval polls at the timeout.
+	if interval > timeout {
+		interval = timeout
+	}
 	return
 }
 
+// validateWaitOptions rejects negative durations, which would otherwise be
+// silently replaced by the defaults, and an explicit Interval longer than the
+// effective Timeout, which would allow only a single poll before timing out.
+func validateWaitOptions(opts *WaitOptions) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.Interval < 0 {
+		return fmt.Errorf("invalid WaitOptions: Interval must not be negative, got %s", opts.Interval)
+	}
+	if opts.Timeout < 0 {
+		return fmt.Errorf("invalid WaitOptions: Timeout must not be negative, got %s", opts.Timeout)
+	}
+	if opts.MaxPolls < 0 {
+		return fmt.Errorf("invalid WaitOptions: MaxPolls must not be negative, got %d", opts.MaxPolls)
+	}
+	if opts.Interval == 0 {
+		return nil
+	}
+	_, timeout := resolveWaitOptions(&WaitOptions{Timeout: opts.Timeout})
+	if opts.Interval > timeout {
+		return fmt.Errorf("invalid WaitOptions: Interval (%s) must not exceed Timeout (%s)", opts.Interval, timeout)
+	}
+	return nil
+}
+
+// WithDefaultWaitOptions sets the polling defaults used by the
+// WaitForCompletion helpers in place of the built-in 3s interval and 10m
+// timeout. Per-call options still win; zero fields in them fall back to these.
+// A default PreferStream applies to every call that does not set
+// DisableStream.
+func WithDefaultWaitOptions(opts WaitOptions) ClientOption {
+	return func(c *clientConfig) { c.waitDefaults = &opts }
+}
+
+// withWaitDefaults fills the unset fields of opts from defaults. Neither
+// argument is modified; either may be nil.
+func withWaitDefaults(opts, defaults *WaitOptions) *WaitOptions {
+	if defaults == nil {
+		return opts
+	}
+	merged := *defaults
+	if opts == nil {
+		return &merged
+	}
+	merged.PreferStream = opts.PreferStream || defaults.PreferStream
+	merged.DisableStream = opts.DisableStream
+	if opts.Interval != 0 {
+		merged.Interval = opts.Interval
+	}
+	if opts.Timeout != 0 {
+		merged.Timeout = opts.Timeout
+	}
+	if opts.Backoff != nil {
+		merged.Backoff = opts.Backoff
+	}
+	if opts.InitialDelay != 0 {
+		merged.InitialDelay = opts.InitialDelay
+	}
+	if opts.OnPoll != nil {
+		merged.OnPoll = opts.OnPoll
+	}
+	if opts.MaxPolls != 0 {
+		merged.MaxPolls = opts.MaxPolls
+	}
+	return &merged
+}
+
 // ClientOption configures the Proof client.
 type ClientOption func(*clientConfig)
 
 type clientConfig struct {
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
+	baseURL        string
+	timeout        time.Duration
+	maxRetries     int
+	retryPredicate RetryPredicate
+	metrics        Metrics
+	backoff        Backoff
+	debug          io.Writer
+	timestamp      bool
+	errorRedactor  ErrorRedactor
+	httpClient     *http.Client
+	timeoutSet     bool
+	minTLSVersion  uint16
+	maxRequestSize int
+	retryConnReset bool
+	waitDefaults   *WaitOptions
+	conditional    bool
+	captureLast    bool
+	jwksRefresh    bool
+	noRetries      bool
 }
 
-// WithBaseURL sets a custom API base URL.
+// WithBaseURL sets a custom API base URL. Trailing slashes are trimmed; the URL
+// must be absolute (scheme and host), otherwise NewClient returns an error.
 func WithBaseURL(url string) ClientOption {
 	return func(c *clientConfig) { c.baseURL = url }
 }
 
-// WithTimeout sets the HTTP request timeout.
+// WithTimeout sets the HTTP request timeout. It cannot be combined with
+// WithHTTPClient; set Timeout on the custom client instead.
 func WithTimeout(d time.Duration) ClientOption {
-	return func(c *clientConfig) { c.timeout = d }
+	return func(c *clientConfig) {
+		c.timeout = d
+		c.timeoutSet = true
+	}
+}
+
+// WithHTTPClient sets the *http.Client used for requests, e.g. to supply a
+// custom transport or proxy. Its Timeout is used as the request timeout, so
+// combining it with WithTimeout is rejected by NewClient.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *clientConfig) { c.httpClient = client }
+}
+
+// WithMinTLSVersion sets the lowest TLS version the default transport will
+// negotiate, e.g. tls.VersionTLS13. The default is TLS 1.2. It is ignored when
+// a custom client is supplied with WithHTTPClient.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *clientConfig) { c.minTLSVersion = version }
+}
+
+// WithMaxRequestBytes rejects requests whose JSON body is larger than n bytes
+// with a *PayloadTooLargeError before anything is sent, rather than leaving it
+// to a gateway's 413. Zero (the default) means no limit.
+func WithMaxRequestBytes(n int) ClientOption {
+	return func(c *clientConfig) { c.maxRequestSize = n }
 }
 
 // WithMaxRetries sets the maximum number of retries for failed requests.
@@ -55,13 +228,66 @@ func WithMaxRetries(n int) ClientOption {
 	return func(c *clientConfig) { c.maxRetries = n }
 }
 
+// WithDisableRetries makes every call a single attempt, for strictly-once
+// semantics. Unlike WithMaxRetries(0) it also turns off
+// WithRetryOnConnectionReset, regardless of option order, and a per-call
+// WithRetries or WithRetriesOpt cannot re-enable retries.
+func WithDisableRetries() ClientOption {
+	return func(c *clientConfig) { c.noRetries = true }
+}
+
+// WithRetryOnConnectionReset retries idempotent requests (GET, HEAD, OPTIONS,
+// PUT, DELETE) whose connection was reset or closed mid-response, even if a
+// RetryPredicate rejects them or retries are otherwise disabled (one retry
+// when WithMaxRetries is 0).
+func WithRetryOnConnectionReset(enabled bool) ClientOption {
+	return func(c *clientConfig) { c.retryConnReset = enabled }
+}
+
+// WithConditionalRequests makes repeat GETs of the same URL conditional: the
+// ETag of the last successful response is sent as If-None-Match, and a 304
+// Not Modified returns the remembered body. Off by default.
+func WithConditionalRequests(enabled bool) ClientOption {
+	return func(c *clientConfig) { c.conditional = enabled }
+}
+
+// WithRequestTimestamp adds an X-Request-Timestamp header (Unix seconds) to
+// every request, regenerated on each retry, for gateways that reject replays.
+func WithRequestTimestamp(enabled bool) ClientOption {
+	return func(c *clientConfig) { c.timestamp = enabled }
+}
+
+// WithJWKSBackgroundRefresh refreshes the signing keys used by
+// Proofs.VerifyOffline on a background goroutine before they expire, so no
+// verification pays for a fetch. The goroutine runs until Client.Close.
+func WithJWKSBackgroundRefresh(enabled bool) ClientOption {
+	return func(c *clientConfig) { c.jwksRefresh = enabled }
+}
+
+// RetryPredicate decides whether a failed attempt should be retried. It receives
+// the zero-based attempt number and either the response (with a readable body)
+// or the transport error. It is only consulted for errors and responses with
+// status >= 400, and never beyond the configured maximum number of retries.
+// 401, 403 and 503 maintenance responses always fail fast without consulting
+// it.
+type RetryPredicate func(attempt int, resp *http.Response, err error) bool
+
+// WithRetryPredicate replaces the default retry policy (network errors, 429
+// and 5xx) with a custom predicate, e.g. to retry based on the error code.
+func WithRetryPredicate(fn RetryPredicate) ClientOption {
+	return func(c *clientConfig) { c.retryPredicate = fn }
+}
+
 // Client is the main proof.holdings API client.
 type Client struct {
-	Verifications        *Verifications
-	VerificationRequests *VerificationRequests
-	Proofs               *Proofs
-	Sessions             *Sessions
-	WebhookDeliveries    *WebhookDeliveries
+	Verifications        VerificationsAPI
+	VerificationRequests VerificationRequestsAPI
+	Proofs               ProofsAPI
+	Sessions             SessionsAPI
+	WebhookDeliveries    WebhookDeliveriesAPI
+
+	http *httpClient
+	jwks *jwksCache
 }
 
 // NewClient creates a new proof.holdings API client.
@@ -71,21 +297,125 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	}
 
 	cfg := &clientConfig{
-		baseURL:    DefaultBaseURL,
-		timeout:    DefaultTimeout,
-		maxRetries: DefaultMaxRetries,
+		baseURL:       DefaultBaseURL,
+		timeout:       DefaultTimeout,
+		maxRetries:    DefaultMaxRetries,
+		backoff:       DefaultBackoff,
+		errorRedactor: DefaultErrorRedactor,
+		minTLSVersion: tls.VersionTLS12,
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	baseURL, err := normalizeBaseURL(cfg.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	cfg.baseURL = baseURL
 
+	if cfg.noRetries {
+		cfg.maxRetries = 0
+		cfg.retryConnReset = false
+	}
 	http := newHTTPClient(apiKey, cfg.baseURL, cfg.timeout, cfg.maxRetries)
+	http.noRetries = cfg.noRetries
+	http.retryPredicate = cfg.retryPredicate
+	http.retryBackoff = cfg.backoff
+	http.debug = newDebugDumper(cfg.debug, apiKey)
+	http.timestamp = cfg.timestamp
+	http.errorRedactor = cfg.errorRedactor
+	http.maxRequestSize = cfg.maxRequestSize
+	http.retryConnReset = cfg.retryConnReset
+	http.waitDefaults = cfg.waitDefaults
+	if cfg.conditional {
+		http.etags = newETagCache()
+	}
+	if cfg.captureLast {
+		http.lastResponse = &responseCapture{}
+	}
+	if cfg.metrics != nil {
+		http.metrics = cfg.metrics
+	}
+	if cfg.httpClient != nil {
+		http.client = cfg.httpClient
+		http.timeout = cfg.httpClient.Timeout
+	} else {
+		http.client.Transport = defaultTransport(cfg.minTLSVersion)
+	}
 
+	jwksURL := cfg.baseURL + "/.well-known/jwks.json"
+	jwks := newJWKSCache(http, jwksURL)
+	if cfg.jwksRefresh {
+		jwks.startRefresher()
+	}
+	proofs := &Proofs{http: http, jwksURL: jwksURL, jwks: jwks}
 	return &Client{
-		Verifications:        &Verifications{http: http},
+		Verifications:        &Verifications{http: http, proofs: proofs},
 		VerificationRequests: &VerificationRequests{http: http},
-		Proofs:               &Proofs{http: http, jwksURL: cfg.baseURL + "/.well-known/jwks.json"},
+		Proofs:               proofs,
 		Sessions:             &Sessions{http: http},
 		WebhookDeliveries:    &WebhookDeliveries{http: http},
+		http:                 http,
+		jwks:                 jwks,
 	}, nil
 }
+
+// Close stops background work started by the client, such as
+// WithJWKSBackgroundRefresh. It does not affect in-flight requests, and is a
+// no-op when nothing was started.
+func (c *Client) Close() error {
+	c.jwks.stop()
+	return nil
+}
+
+// validate rejects option combinations whose outcome would be ambiguous.
+func (c *clientConfig) validate() error {
+	if c.httpClient != nil && c.timeoutSet {
+		return errors.New("conflicting options: WithTimeout has no effect with WithHTTPClient; set Timeout on the custom http.Client instead")
+	}
+	if err := validateWaitOptions(c.waitDefaults); err != nil {
+		return fmt.Errorf("default wait options: %w", err)
+	}
+	if c.maxRequestSize < 0 {
+		return fmt.Errorf("max request size must be >= 0, got %d", c.maxRequestSize)
+	}
+	switch c.minTLSVersion {
+	case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+	default:
+		return fmt.Errorf("invalid minimum TLS version %#04x: use a tls.VersionTLS* constant", c.minTLSVersion)
+	}
+	return nil
+}
+
+// defaultTransport returns a copy of http.DefaultTransport that refuses TLS
+// versions below minVersion.
+func defaultTransport(minVersion uint16) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.MinVersion = minVersion
+	return transport
+}
+
+// normalizeBaseURL trims trailing slashes so paths join cleanly and checks the
+// result is an absolute http(s) URL. A query string or fragment is rejected,
+// since request paths are appended to the base URL and would land after it.
+func normalizeBaseURL(raw string) (string, error) {
+	trimmed := strings.TrimRight(strings.TrimSpace(raw), "/")
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL like %q", raw, DefaultBaseURL)
+	}
+	if u.RawQuery != "" || u.ForceQuery || strings.Contains(trimmed, "#") {
+		return "", fmt.Errorf("invalid base URL %q: must not have a query string or fragment", raw)
+	}
+	return trimmed, nil
+}
@@ -1,6 +1,18 @@
 package proof
 
-import "testing"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
 
 func TestNewClient_EmptyKey(t *testing.T) {
 	_, err := NewClient("")
@@ -64,3 +76,130 @@ func TestResolveWaitOptions_Custom(t *testing.T) {
 		t.Errorf("expected 30s timeout, got %v", timeout)
 	}
 }
+
+func TestNewClient_BaseURLTrailingSlash(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"id":"ver_1"}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("pk_test_123", WithBaseURL(srv.URL+"//"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Verifications.Retrieve(context.Background(), "ver_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/api/v1/verifications/ver_1" {
+		t.Errorf("want path '/api/v1/verifications/ver_1', got %q", gotPath)
+	}
+}
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	for _, raw := range []string{
+		"api.proof.holdings", "://bad", "ftp://api.proof.holdings", "",
+		"https://api.proof.holdings?region=eu", "https://api.proof.holdings/?", "https://api.proof.holdings#v1", "https://api.proof.holdings/#",
+	} {
+		if _, err := NewClient("pk_test_123", WithBaseURL(raw)); err == nil {
+			t.Errorf("expected error for base URL %q", raw)
+		}
+	}
+}
+
+func TestNewClient_WithHTTPClient(t *testing.T) {
+	var used bool
+	custom := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		used = true
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	})}
+
+	client, err := NewClient("pk_test_123", WithHTTPClient(custom))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Proofs.ListRevoked(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !used {
+		t.Error("custom http.Client was not used")
+	}
+}
+
+func TestNewClient_MinTLSVersion(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	newClient := func(opts ...ClientOption) *Client {
+		t.Helper()
+		client, err := NewClient("pk_test_123", append(opts, WithBaseURL(srv.URL), WithMaxRetries(0))...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Trust the test server's certificate so only the version matters.
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		client.http.client.Transport.(*http.Transport).TLSClientConfig.RootCAs = pool
+		return client
+	}
+
+	if _, err := newClient().Proofs.ListRevoked(context.Background()); err != nil {
+		t.Fatalf("TLS 1.2 server should be accepted by default, got %v", err)
+	}
+	_, err := newClient(WithMinTLSVersion(tls.VersionTLS13)).Proofs.ListRevoked(context.Background())
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("want handshake failure as NetworkError, got %T: %v", err, err)
+	}
+}
+
+func TestNewClient_InvalidMinTLSVersion(t *testing.T) {
+	if _, err := NewClient("pk_test_123", WithMinTLSVersion(0x9999)); err == nil {
+		t.Fatal("expected error for unknown TLS version")
+	}
+}
+
+func TestNewClient_ConflictingOptions(t *testing.T) {
+	_, err := NewClient("pk_test_123", WithHTTPClient(&http.Client{}), WithTimeout(5*time.Second))
+	if err == nil {
+		t.Fatal("expected error for WithHTTPClient combined with WithTimeout")
+	}
+	if !strings.Contains(err.Error(), "WithTimeout") {
+		t.Errorf("error should name the conflicting option, got %q", err)
+	}
+}
+
+func TestNewClient_WithDisableRetries(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		w.WriteHeader(500)
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(3), WithDisableRetries(), WithRetryOnConnectionReset(true))
+	_, err := client.Verifications.Retrieve(context.Background(), "ver_1")
+	var sErr *ServerError
+	if !errors.As(err, &sErr) {
+		t.Fatalf("want ServerError, got %T: %v", err, err)
+	}
+	if callCount.Load() != 1 {
+		t.Errorf("want exactly one attempt, got %d", callCount.Load())
+	}
+	if client.http.maxRetries != 0 || client.http.retryConnReset {
+		t.Errorf("want all retries disabled, got maxRetries=%d retryConnReset=%v", client.http.maxRetries, client.http.retryConnReset)
+	}
+
+	callCount.Store(0)
+	client.Verifications.Retrieve(WithRetries(context.Background(), 3), "ver_1")
+	client.RawRequest(context.Background(), http.MethodGet, "/api/v1/verifications/ver_1", nil, WithRetriesOpt(3))
+	if callCount.Load() != 2 {
+		t.Errorf("want per-call retry overrides ignored, got %d attempts for 2 calls", callCount.Load())
+	}
+}
@@ -0,0 +1,35 @@
+package proof
+
+import (
+	"context"
+	"time"
+)
+
+// clock is the time source for request timestamps and polling. Tests replace
+// it with a fake so timeout paths run without real sleeping.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// sleepCtx waits for d, returning early with ctx.Err() if ctx is done first.
+// Every wait outside the injectable clock goes through it, so no wait in the
+// SDK outlives its context.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
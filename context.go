@@ -0,0 +1,202 @@
+package proof
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type contextKey int
+
+const (
+	retriesKey contextKey = iota
+	baseURLOverrideKey
+	sourceKey
+	headersKey
+	rawQueryKey
+)
+
+// WithRetries returns a context that overrides the client's maximum number of
+// retries for calls made with it, e.g. 0 for a non-idempotent call or more for
+// a known-flaky batch. n must be >= 0; calls made with a negative override fail
+// before any request is sent. It cannot re-enable retries on a client built
+// with WithDisableRetries.
+func WithRetries(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, retriesKey, n)
+}
+
+func retriesFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(retriesKey).(int)
+	return n, ok
+}
+
+// WithBaseURLOverride returns a context that sends calls made with it to
+// baseURL instead of the client's base URL, e.g. to route a fraction of
+// traffic to a canary host. The override is normalized like WithBaseURL; an
+// invalid URL makes those calls fail before any request is sent.
+func WithBaseURLOverride(ctx context.Context, baseURL string) context.Context {
+	return context.WithValue(ctx, baseURLOverrideKey, baseURL)
+}
+
+func baseURLOverrideFromContext(ctx context.Context) (string, bool) {
+	u, ok := ctx.Value(baseURLOverrideKey).(string)
+	return u, ok
+}
+
+// maxSourceLength bounds WithSource labels.
+const maxSourceLength = 64
+
+// WithSource returns a context that tags calls made with it with an
+// X-Proof-Source header, e.g. "checkout" or "onboarding/v2", for server-side
+// analytics. Labels must be 1-64 characters of ASCII letters, digits and
+// ". _ - : /"; calls with an invalid label fail before any request is sent.
+func WithSource(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, sourceKey, label)
+}
+
+func sourceFromContext(ctx context.Context) (string, bool) {
+	label, ok := ctx.Value(sourceKey).(string)
+	return label, ok
+}
+
+func validateSource(label string) error {
+	if label == "" || len(label) > maxSourceLength {
+		return fmt.Errorf("source label must be 1-%d characters, got %d", maxSourceLength, len(label))
+	}
+	for _, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '_', r == '-', r == ':', r == '/':
+		default:
+			return fmt.Errorf("source label %q contains invalid character %q", label, r)
+		}
+	}
+	return nil
+}
+
+// WithHeader returns a context that adds the header key: value to calls made
+// with it, on top of the client's default headers. Repeated calls accumulate,
+// including repeated values for the same key. Headers the SDK sets itself
+// (Authorization, Content-Type, Accept, User-Agent, X-Proof-Source and
+// X-Request-Timestamp) cannot be set this way, and neither can a malformed
+// name or a value containing control characters such as CR or LF; calls
+// carrying one fail before any request is sent.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	headers := headersFromContext(ctx).Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Add(key, value)
+	return context.WithValue(ctx, headersKey, headers)
+}
+
+func headersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(headersKey).(http.Header)
+	return headers
+}
+
+// sdkHeaders are the headers setHeaders owns. WithHeader cannot set them, as
+// they would otherwise be sent twice or override the client's own values.
+var sdkHeaders = map[string]bool{
+	"Authorization":       true,
+	"Content-Type":        true,
+	"Accept":              true,
+	"User-Agent":          true,
+	"X-Proof-Source":      true,
+	"X-Request-Timestamp": true,
+}
+
+func validateHeaders(headers http.Header) error {
+	if _, ok := headers["Authorization"]; ok {
+		return fmt.Errorf("the Authorization header cannot be set with WithHeader; it is derived from the API key")
+	}
+	for key, values := range headers {
+		if sdkHeaders[key] {
+			return fmt.Errorf("the %s header cannot be set with WithHeader; it is set by the SDK", key)
+		}
+		if !validHeaderName(key) {
+			return fmt.Errorf("invalid header name %q", key)
+		}
+		for _, value := range values {
+			if !validHeaderValue(value) {
+				return fmt.Errorf("invalid value for header %s: %q contains a control character", key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is a non-empty RFC 7230 token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderValue reports whether value is free of control characters other
+// than horizontal tab, as net/http requires; CR and LF in particular would
+// otherwise fail every attempt as a retryable transport error.
+func validHeaderValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < ' ' && c != '\t') || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRawQuery returns a context that adds query to GET calls made with it,
+// for server parameters the SDK does not model yet. Repeated calls accumulate.
+// Parameters set by the method itself, or in a RawRequest path, take
+// precedence: a key already sent is not overridden or duplicated. Non-GET
+// calls ignore the extra query.
+func WithRawQuery(ctx context.Context, query url.Values) context.Context {
+	merged := url.Values{}
+	for k, vs := range rawQueryFromContext(ctx) {
+		merged[k] = append([]string(nil), vs...)
+	}
+	for k, vs := range query {
+		merged[k] = append(merged[k], vs...)
+	}
+	return context.WithValue(ctx, rawQueryKey, merged)
+}
+
+func rawQueryFromContext(ctx context.Context) url.Values {
+	query, _ := ctx.Value(rawQueryKey).(url.Values)
+	return query
+}
+
+// mergeRawQuery returns query plus the keys of extra it does not already set,
+// dropping empty values like queryFromMap does. query is not modified.
+func mergeRawQuery(query, extra url.Values) url.Values {
+	if len(extra) == 0 {
+		return query
+	}
+	merged := url.Values{}
+	for k, vs := range query {
+		merged[k] = vs
+	}
+	for k, vs := range extra {
+		if _, ok := merged[k]; ok {
+			continue
+		}
+		for _, v := range vs {
+			if v != "" {
+				merged.Add(k, v)
+			}
+		}
+	}
+	return merged
+}
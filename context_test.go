@@ -0,0 +1,219 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetries_ZeroPreventsRetry(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		w.WriteHeader(500)
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 5e9, 2)
+	_, err := client.get(WithRetries(context.Background(), 0), "/test", nil)
+	var sErr *ServerError
+	if !errors.As(err, &sErr) {
+		t.Fatalf("want ServerError, got %T: %v", err, err)
+	}
+	if callCount.Load() != 1 {
+		t.Errorf("want 1 call, got %d", callCount.Load())
+	}
+}
+
+func TestWithRetries_IncreasesRetries(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		w.WriteHeader(500)
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 5e9, 0)
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	client.get(WithRetries(context.Background(), 3), "/test", nil)
+	if callCount.Load() != 4 {
+		t.Errorf("want 4 calls, got %d", callCount.Load())
+	}
+}
+
+func TestWithRetries_Negative(t *testing.T) {
+	client := newHTTPClient("pk_test_123", "http://127.0.0.1:1", 5e9, 0)
+	if _, err := client.get(WithRetries(context.Background(), -1), "/test", nil); err == nil {
+		t.Fatal("expected error for negative retries override")
+	}
+}
+
+func TestWithBaseURLOverride(t *testing.T) {
+	var defaultHits, canaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultHits.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer primary.Close()
+	canary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		canaryHits.Add(1)
+		if r.URL.Path != "/api/v1/verifications/ver_1" {
+			t.Errorf("unexpected canary path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer canary.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(primary.URL))
+	ctx := context.Background()
+	client.Verifications.Retrieve(ctx, "ver_1")
+	client.Verifications.Retrieve(WithBaseURLOverride(ctx, canary.URL+"/"), "ver_1")
+	client.Verifications.Retrieve(ctx, "ver_1")
+
+	if defaultHits.Load() != 2 || canaryHits.Load() != 1 {
+		t.Errorf("want 2 default and 1 canary hits, got %d and %d", defaultHits.Load(), canaryHits.Load())
+	}
+}
+
+func TestWithBaseURLOverride_Invalid(t *testing.T) {
+	client, _ := NewClient("pk_test_123")
+	_, err := client.Verifications.Retrieve(WithBaseURLOverride(context.Background(), "not a url"), "ver_1")
+	if err == nil {
+		t.Fatal("expected error for invalid override")
+	}
+}
+
+func TestWithSource(t *testing.T) {
+	var sources []string
+	var present []bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Header["X-Proof-Source"]
+		present = append(present, ok)
+		sources = append(sources, r.Header.Get("X-Proof-Source"))
+		w.Write([]byte(`{"id":"ver_1"}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := context.Background()
+	if _, err := client.Verifications.Create(WithSource(ctx, "checkout/v2"), map[string]any{"type": "phone"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Verifications.Create(ctx, map[string]any{"type": "phone"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !present[0] || sources[0] != "checkout/v2" {
+		t.Errorf("want X-Proof-Source 'checkout/v2', got %q", sources[0])
+	}
+	if present[1] {
+		t.Errorf("want no X-Proof-Source header when unset, got %q", sources[1])
+	}
+}
+
+func TestWithSource_Invalid(t *testing.T) {
+	client, _ := NewClient("pk_test_123", WithBaseURL("http://127.0.0.1:1"))
+	for _, label := range []string{"", "has space", "ünicode", strings.Repeat("a", 65)} {
+		_, err := client.Verifications.Create(WithSource(context.Background(), label), nil)
+		if err == nil || !strings.Contains(err.Error(), "source label") {
+			t.Errorf("label %q: want source label error, got %v", label, err)
+		}
+	}
+}
+
+func TestWithHeader_Accumulates(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"id":"ver_1"}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := WithHeader(context.Background(), "X-Tenant", "acme")
+	ctx = WithHeader(ctx, "X-Trace", "abc")
+	if _, err := client.Verifications.Retrieve(ctx, "ver_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Get("X-Tenant") != "acme" || got.Get("X-Trace") != "abc" {
+		t.Errorf("want both context headers, got %v", got)
+	}
+	if got.Get("Authorization") != "Bearer pk_test_123" {
+		t.Errorf("default headers should be kept, got Authorization %q", got.Get("Authorization"))
+	}
+}
+
+func TestWithHeader_RejectsAuthorization(t *testing.T) {
+	client, _ := NewClient("pk_test_123", WithBaseURL("http://127.0.0.1:1"))
+	ctx := WithHeader(context.Background(), "authorization", "Bearer pk_live_other")
+	_, err := client.Verifications.Retrieve(ctx, "ver_1")
+	if err == nil || !strings.Contains(err.Error(), "Authorization") {
+		t.Fatalf("want Authorization rejection, got %v", err)
+	}
+}
+
+func TestWithHeader_RejectsInvalidHeaders(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"id":"ver_1"}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	tests := map[string]struct{ key, value string }{
+		"content type":  {"Content-Type", "text/plain"},
+		"accept":        {"accept", "text/html"},
+		"user agent":    {"User-Agent", "my-app/1.0"},
+		"source":        {"X-Proof-Source", "checkout"},
+		"CRLF in value": {"X-Tenant", "acme\r\nX-Injected: 1"},
+		"NUL in value":  {"X-Tenant", "acme\x00"},
+		"invalid name":  {"X Tenant", "acme"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithHeader(context.Background(), tt.key, tt.value)
+			if _, err := client.Verifications.Retrieve(ctx, "ver_1"); err == nil {
+				t.Fatal("expected the header to be rejected")
+			}
+		})
+	}
+	if calls.Load() != 0 {
+		t.Errorf("want no requests sent, got %d", calls.Load())
+	}
+
+	ctx := WithHeader(context.Background(), "X-Tenant", "acme\tcorp")
+	if _, err := client.Verifications.Retrieve(ctx, "ver_1"); err != nil {
+		t.Errorf("a tab in a header value is valid: %v", err)
+	}
+}
+
+func TestWithRawQuery(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := WithRawQuery(context.Background(), url.Values{"include": {"assets"}, "status": {"failed"}})
+	ctx = WithRawQuery(ctx, url.Values{"region": {"eu"}})
+	if _, err := client.Verifications.List(ctx, map[string]string{"status": "verified"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Get("include") != "assets" || got.Get("region") != "eu" {
+		t.Errorf("want extra query params sent, got %v", got)
+	}
+	if s := got["status"]; len(s) != 1 || s[0] != "verified" {
+		t.Errorf("method-supplied status should take precedence, got %v", s)
+	}
+}
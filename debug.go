@@ -0,0 +1,115 @@
+package proof
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// WithDebug writes a full dump of every HTTP attempt (request and response,
+// including bodies) to w. The API key is redacted. Intended for local
+// development only: dumps may contain personal data such as phone numbers.
+func WithDebug(w io.Writer) ClientOption {
+	return func(c *clientConfig) { c.debug = w }
+}
+
+// debugDumper serializes HTTP exchange dumps to a writer.
+type debugDumper struct {
+	mu     sync.Mutex
+	w      io.Writer
+	apiKey string
+}
+
+func newDebugDumper(w io.Writer, apiKey string) *debugDumper {
+	if w == nil {
+		return nil
+	}
+	return &debugDumper{w: w, apiKey: apiKey}
+}
+
+func (d *debugDumper) request(req *http.Request, attempt int) {
+	if d == nil {
+		return
+	}
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		d.write(attempt, "request", []byte("failed to dump request: "+err.Error()))
+		return
+	}
+	d.write(attempt, "request", dump)
+}
+
+func (d *debugDumper) response(resp *http.Response, attempt int) {
+	if d == nil {
+		return
+	}
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		d.write(attempt, "response", []byte("failed to dump response: "+err.Error()))
+		return
+	}
+	d.write(attempt, "response", dump)
+}
+
+func (d *debugDumper) transportError(err error, attempt int) {
+	if d == nil {
+		return
+	}
+	d.write(attempt, "error", []byte(err.Error()))
+}
+
+func (d *debugDumper) write(attempt int, kind string, dump []byte) {
+	if d.apiKey != "" {
+		dump = bytes.ReplaceAll(dump, []byte(d.apiKey), []byte("[REDACTED]"))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fmt.Fprintf(d.w, "--- proof debug: %s (attempt %d) ---\n%s\n\n", kind, attempt+1, bytes.TrimRight(dump, "\r\n"))
+}
+
+// maxCapturedResponse bounds the body kept by WithCaptureLastResponse.
+const maxCapturedResponse = 64 * 1024
+
+// WithCaptureLastResponse keeps the raw body of the most recent response
+// (success or error, truncated to 64 KiB) for Client.LastRawResponse. It is a
+// debugging aid for inspecting one problematic call without WithDebug; with
+// concurrent calls, "last" is whichever finished most recently.
+func WithCaptureLastResponse(enabled bool) ClientOption {
+	return func(c *clientConfig) { c.captureLast = enabled }
+}
+
+// responseCapture holds the last response body.
+type responseCapture struct {
+	mu   sync.Mutex
+	body []byte
+}
+
+func (c *responseCapture) store(body []byte) {
+	if c == nil {
+		return
+	}
+	if len(body) > maxCapturedResponse {
+		body = body[:maxCapturedResponse]
+	}
+	c.mu.Lock()
+	c.body = append([]byte(nil), body...)
+	c.mu.Unlock()
+}
+
+func (c *responseCapture) load() []byte {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.body...)
+}
+
+// LastRawResponse returns a copy of the raw body of the most recent response
+// when WithCaptureLastResponse is enabled, and nil otherwise.
+func (c *Client) LastRawResponse() []byte {
+	return c.http.lastResponse.load()
+}
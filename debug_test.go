@@ -0,0 +1,70 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithDebug_DumpsExchangeWithRedaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_123", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client, _ := NewClient("pk_test_secret", WithBaseURL(srv.URL), WithDebug(&buf))
+	result, err := client.Verifications.Create(context.Background(), map[string]any{"type": "phone"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["id"] != "ver_123" {
+		t.Errorf("response body should still be parsed after dumping, got %v", result)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"POST /api/v1/verifications HTTP/1.1",
+		"Authorization: Bearer [REDACTED]",
+		`{"type":"phone"}`,
+		"HTTP/1.1 201 Created",
+		`"id":"ver_123"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "pk_test_secret") {
+		t.Errorf("debug output leaked the API key:\n%s", out)
+	}
+}
+
+func TestWithCaptureLastResponse(t *testing.T) {
+	body := `{"id":"ver_1","status":"pending","unexpected": [1, 2]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithCaptureLastResponse(true))
+	if got := client.LastRawResponse(); got != nil {
+		t.Errorf("want nil before any call, got %q", got)
+	}
+	if _, err := client.Verifications.Retrieve(context.Background(), "ver_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(client.LastRawResponse()); got != body {
+		t.Errorf("want captured body %q, got %q", body, got)
+	}
+
+	plain, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	plain.Verifications.Retrieve(context.Background(), "ver_1")
+	if got := plain.LastRawResponse(); got != nil {
+		t.Errorf("capture should be off by default, got %q", got)
+	}
+}
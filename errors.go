@@ -1,8 +1,10 @@
 package proof
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // ProofError is the base error type for all API errors.
@@ -10,14 +12,23 @@ type ProofError struct {
 	Message    string `json:"message"`              // Human-readable error message
 	Code       string `json:"code"`                 // Machine-readable error code
 	StatusCode int    `json:"status_code"`          // HTTP status code
-	Details    any    `json:"details,omitempty"`     // Additional error context
+	Details    any    `json:"details,omitempty"`    // Additional error context
 	RequestID  string `json:"request_id,omitempty"` // Server request ID for debugging
 }
 
 func (e *ProofError) Error() string {
+	if text := e.StatusText(); text != "" {
+		return fmt.Sprintf("%s (code: %s, status: %d %s)", e.Message, e.Code, e.StatusCode, text)
+	}
 	return fmt.Sprintf("%s (code: %s, status: %d)", e.Message, e.Code, e.StatusCode)
 }
 
+// StatusText returns the standard text for StatusCode (e.g. "Not Found"), or
+// "" for locally generated errors and unknown codes.
+func (e *ProofError) StatusText() string {
+	return http.StatusText(e.StatusCode)
+}
+
 // Typed error subtypes for specific HTTP status codes.
 
 type ValidationError struct{ ProofError }
@@ -25,19 +36,81 @@ type AuthenticationError struct{ ProofError }
 type ForbiddenError struct{ ProofError }
 type NotFoundError struct{ ProofError }
 type ConflictError struct{ ProofError }
+
 // RateLimitError includes optional lockout fields for auth rate limiting.
 type RateLimitError struct {
 	ProofError
-	// RetryAfter is the number of seconds to wait before retrying (from error response retryAfter field).
+	// RetryAfter is the number of seconds to wait before retrying (from error response retryAfter field,
+	// or the Retry-After header when the body has none).
 	RetryAfter *int
 	// RemainingAttempts is the number of remaining attempts before lockout (auth endpoints only).
 	RemainingAttempts *int
 }
+
+// IncorrectCodeError is returned when a submitted OTP/challenge code is wrong
+// but further attempts are still allowed. Once attempts are exhausted the API
+// responds with a RateLimitError instead. It unwraps to a *ValidationError.
+type IncorrectCodeError struct {
+	ProofError
+	// RemainingAttempts is the number of attempts left before lockout, if reported.
+	RemainingAttempts *int
+}
+
+func (e *IncorrectCodeError) Unwrap() error { return &ValidationError{e.ProofError} }
+
+// PayloadTooLargeError is returned for a 413 response, or locally when a
+// request body exceeds the WithMaxRequestBytes limit (StatusCode 0).
+type PayloadTooLargeError struct{ ProofError }
+
 type ServerError struct{ ProofError }
 type NetworkError struct{ ProofError }
-type TimeoutError struct{ ProofError }
+
+// MaintenanceError is returned for a 503 with error code "maintenance", when
+// the API is down for planned maintenance. It unwraps to a *ServerError.
+type MaintenanceError struct {
+	ProofError
+	// RetryAfter is the number of seconds to wait before retrying, if reported.
+	RetryAfter *int
+	// EndsAt is the estimated end of the maintenance window, from the
+	// "ends_at" detail or else the Retry-After header; zero if unknown.
+	EndsAt time.Time
+}
+
+func (e *MaintenanceError) Unwrap() error { return &ServerError{e.ProofError} }
+
+// TimeoutSource identifies which deadline caused a TimeoutError.
+type TimeoutSource string
+
+const (
+	// TimeoutSourceClient means the client-wide timeout (WithTimeout) elapsed.
+	TimeoutSourceClient TimeoutSource = "client"
+	// TimeoutSourceContext means the caller's context was cancelled or its deadline passed.
+	TimeoutSourceContext TimeoutSource = "context"
+)
+
+// TimeoutError includes the effective timeout and where it came from.
+type TimeoutError struct {
+	ProofError
+	// Timeout is the deadline that was applied. For context timeouts it is the
+	// time remaining on the context when the request started, or zero if the
+	// context had no deadline (plain cancellation).
+	Timeout time.Duration
+	// Source reports whether the client config or the context imposed the timeout.
+	Source TimeoutSource
+}
 type PollingTimeoutError struct{ ProofError }
 
+// PollingTerminatedError is returned when a resource that was found on an
+// earlier poll responds 404 on a later one, i.e. it was deleted while being
+// waited on. A 404 on the first poll is returned as a plain *NotFoundError.
+// It unwraps to that *NotFoundError.
+type PollingTerminatedError struct {
+	ProofError
+	Err error
+}
+
+func (e *PollingTerminatedError) Unwrap() error { return e.Err }
+
 func errorFromResponse(statusCode int, apiErr *apiErrorBody) error {
 	code := fmt.Sprintf("http_%d", statusCode)
 	message := fmt.Sprintf("Request failed with status %d", statusCode)
@@ -65,6 +138,9 @@ func errorFromResponse(statusCode int, apiErr *apiErrorBody) error {
 
 	switch statusCode {
 	case http.StatusBadRequest:
+		if apiErr != nil && apiErr.Code == "incorrect_code" {
+			return &IncorrectCodeError{ProofError: base, RemainingAttempts: apiErr.RemainingAttempts}
+		}
 		return &ValidationError{base}
 	case http.StatusUnauthorized:
 		return &AuthenticationError{base}
@@ -74,6 +150,8 @@ func errorFromResponse(statusCode int, apiErr *apiErrorBody) error {
 		return &NotFoundError{base}
 	case http.StatusConflict:
 		return &ConflictError{base}
+	case http.StatusRequestEntityTooLarge:
+		return &PayloadTooLargeError{base}
 	case http.StatusTooManyRequests:
 		rl := &RateLimitError{ProofError: base}
 		if apiErr != nil {
@@ -81,6 +159,11 @@ func errorFromResponse(statusCode int, apiErr *apiErrorBody) error {
 			rl.RemainingAttempts = apiErr.RemainingAttempts
 		}
 		return rl
+	case http.StatusServiceUnavailable:
+		if apiErr != nil && apiErr.Code == "maintenance" {
+			return &MaintenanceError{ProofError: base, RetryAfter: apiErr.RetryAfter}
+		}
+		return &ServerError{base}
 	default:
 		if statusCode >= http.StatusInternalServerError {
 			return &ServerError{base}
@@ -89,6 +172,21 @@ func errorFromResponse(statusCode int, apiErr *apiErrorBody) error {
 	}
 }
 
+// RemainingAttempts reports how many code submissions are left according to an
+// error returned by Verifications.Submit. It returns false when err carries no
+// attempt information.
+func RemainingAttempts(err error) (int, bool) {
+	var codeErr *IncorrectCodeError
+	if errors.As(err, &codeErr) && codeErr.RemainingAttempts != nil {
+		return *codeErr.RemainingAttempts, true
+	}
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) && rlErr.RemainingAttempts != nil {
+		return *rlErr.RemainingAttempts, true
+	}
+	return 0, false
+}
+
 type apiErrorBody struct {
 	Code              string `json:"code"`
 	Message           string `json:"message"`
@@ -15,6 +15,7 @@ func TestErrorFromResponse_StatusMapping(t *testing.T) {
 		{403, "*proof.ForbiddenError"},
 		{404, "*proof.NotFoundError"},
 		{409, "*proof.ConflictError"},
+		{413, "*proof.PayloadTooLargeError"},
 		{429, "*proof.RateLimitError"},
 		{500, "*proof.ServerError"},
 		{502, "*proof.ServerError"},
@@ -34,6 +35,8 @@ func TestErrorFromResponse_StatusMapping(t *testing.T) {
 			got = "*proof.NotFoundError"
 		case *ConflictError:
 			got = "*proof.ConflictError"
+		case *PayloadTooLargeError:
+			got = "*proof.PayloadTooLargeError"
 		case *RateLimitError:
 			got = "*proof.RateLimitError"
 		case *ServerError:
@@ -113,3 +116,34 @@ func TestError_ImplementsError(t *testing.T) {
 		t.Error("Error() should not be empty")
 	}
 }
+
+func TestError_StatusText(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{400, "Bad Request"},
+		{404, "Not Found"},
+		{429, "Too Many Requests"},
+		{503, "Service Unavailable"},
+		{0, ""},
+		{599, ""},
+	}
+	for _, tt := range tests {
+		e := &ProofError{StatusCode: tt.status}
+		if got := e.StatusText(); got != tt.want {
+			t.Errorf("StatusText() for %d: want %q, got %q", tt.status, tt.want, got)
+		}
+	}
+}
+
+func TestError_MessageIncludesStatusText(t *testing.T) {
+	e := &ProofError{Message: "Not found", Code: "not_found", StatusCode: 404}
+	if got, want := e.Error(), "Not found (code: not_found, status: 404 Not Found)"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	local := &ProofError{Message: "Bad option", Code: "invalid", StatusCode: 0}
+	if got, want := local.Error(), "Bad option (code: invalid, status: 0)"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
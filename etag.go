@@ -0,0 +1,42 @@
+package proof
+
+import "sync"
+
+// maxETagEntries bounds the conditional request cache; when full, an
+// arbitrary entry is evicted.
+const maxETagEntries = 256
+
+// etagCache remembers the ETag and body of successful GET responses by URL so
+// repeat requests can be made conditional with If-None-Match.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+func (c *etagCache) get(url string) (etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[url]
+	return e, ok
+}
+
+func (c *etagCache) put(url, etag string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxETagEntries {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[url] = etagEntry{etag: etag, body: body}
+}
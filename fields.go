@@ -0,0 +1,79 @@
+package proof
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// lookup walks nested maps along path and returns the value at the end.
+func lookup(m map[string]any, path ...string) (any, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+	var cur any = m
+	for _, key := range path {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// GetString reads a string at the nested path, e.g.
+// GetString(v, "data", "status"). It returns false if any key is missing or
+// the value is not a string.
+func GetString(m map[string]any, path ...string) (string, bool) {
+	v, _ := lookup(m, path...)
+	s, ok := v.(string)
+	return s, ok
+}
+
+// GetInt reads an integer at the nested path. JSON numbers decode as float64,
+// so integral floats are accepted; fractional values return false.
+func GetInt(m map[string]any, path ...string) (int, bool) {
+	v, _ := lookup(m, path...)
+	switch n := v.(type) {
+	case float64:
+		// -float64(math.MinInt) is exactly MaxInt+1; float64(math.MaxInt)
+		// would round up to it and let an overflowing value through.
+		if n != math.Trunc(n) || n >= -float64(math.MinInt) || n < math.MinInt {
+			return 0, false
+		}
+		return int(n), true
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
+
+// GetBool reads a boolean at the nested path.
+func GetBool(m map[string]any, path ...string) (bool, bool) {
+	v, _ := lookup(m, path...)
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// GetTime reads a timestamp at the nested path. RFC 3339 strings and numeric
+// Unix seconds are accepted.
+func GetTime(m map[string]any, path ...string) (time.Time, bool) {
+	v, _ := lookup(m, path...)
+	switch t := v.(type) {
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		return parsed, err == nil
+	case float64:
+		sec, frac := math.Modf(t)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,99 @@
+package proof
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func testResource(t *testing.T) map[string]any {
+	t.Helper()
+	var m map[string]any
+	err := json.Unmarshal([]byte(`{
+		"id": "ver_1",
+		"verified": true,
+		"created_at": "2026-01-02T03:04:05Z",
+		"expires_at": 1767322800,
+		"pagination": {"total": 5000, "ratio": 0.5, "has_more": false},
+		"data": {"user": {"email": "a@example.com"}}
+	}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestGetString(t *testing.T) {
+	m := testResource(t)
+	if s, ok := GetString(m, "data", "user", "email"); !ok || s != "a@example.com" {
+		t.Errorf("nested: got (%q, %v)", s, ok)
+	}
+	if _, ok := GetString(m, "data", "missing", "email"); ok {
+		t.Error("missing path should return false")
+	}
+	if _, ok := GetString(m, "verified"); ok {
+		t.Error("wrong type should return false")
+	}
+	if _, ok := GetString(m, "id", "nested"); ok {
+		t.Error("walking through a non-map should return false")
+	}
+	if _, ok := GetString(m); ok {
+		t.Error("empty path should return false")
+	}
+	if _, ok := GetString(nil, "id"); ok {
+		t.Error("nil map should return false")
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	m := testResource(t)
+	if n, ok := GetInt(m, "pagination", "total"); !ok || n != 5000 {
+		t.Errorf("total: got (%d, %v)", n, ok)
+	}
+	if _, ok := GetInt(m, "pagination", "ratio"); ok {
+		t.Error("fractional number should return false")
+	}
+	if _, ok := GetInt(m, "id"); ok {
+		t.Error("string should return false")
+	}
+	if n, ok := GetInt(map[string]any{"n": json.Number("7")}, "n"); !ok || n != 7 {
+		t.Errorf("json.Number: got (%d, %v)", n, ok)
+	}
+	if n, ok := GetInt(map[string]any{"n": -float64(math.MinInt)}, "n"); ok {
+		t.Errorf("MaxInt+1 should return false, got %d", n)
+	}
+	if n, ok := GetInt(map[string]any{"n": float64(math.MinInt)}, "n"); !ok || n != math.MinInt {
+		t.Errorf("MinInt: got (%d, %v)", n, ok)
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	m := testResource(t)
+	if b, ok := GetBool(m, "verified"); !ok || !b {
+		t.Errorf("verified: got (%v, %v)", b, ok)
+	}
+	if b, ok := GetBool(m, "pagination", "has_more"); !ok || b {
+		t.Errorf("has_more: got (%v, %v)", b, ok)
+	}
+	if _, ok := GetBool(m, "id"); ok {
+		t.Error("string should return false")
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	m := testResource(t)
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got, ok := GetTime(m, "created_at"); !ok || !got.Equal(want) {
+		t.Errorf("created_at: got (%v, %v)", got, ok)
+	}
+	if got, ok := GetTime(m, "expires_at"); !ok || got.Unix() != 1767322800 {
+		t.Errorf("expires_at: got (%v, %v)", got, ok)
+	}
+	if _, ok := GetTime(m, "id"); ok {
+		t.Error("non-timestamp string should return false")
+	}
+	if _, ok := GetTime(m, "verified"); ok {
+		t.Error("bool should return false")
+	}
+}
@@ -0,0 +1,143 @@
+package proof
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// healthWindow is how many recent request latencies HealthReport's
+// percentiles are computed over.
+const healthWindow = 256
+
+// HealthReport is a snapshot of the client's recent behaviour, for ops
+// dashboards. Counts cover the client's lifetime; latency percentiles cover
+// the last 256 requests.
+type HealthReport struct {
+	// Requests counts logical requests, each including its retries.
+	Requests int64
+	// Errors counts failed requests by kind: "validation", "authentication",
+	// "forbidden", "not_found", "conflict", "rate_limit", "payload_too_large",
+	// "maintenance", "server", "timeout", "network" or "other".
+	Errors map[string]int64
+	P50    time.Duration
+	P95    time.Duration
+	// LastRateLimit is when a 429 was last received, even if a retry then
+	// succeeded; zero if never.
+	LastRateLimit time.Time
+}
+
+// healthStats accumulates what HealthReport returns. It is always on: the
+// cost per request is one short critical section.
+type healthStats struct {
+	mu            sync.Mutex
+	requests      int64
+	errors        map[string]int64
+	latencies     [healthWindow]time.Duration
+	next          int
+	lastRateLimit time.Time
+}
+
+func newHealthStats() *healthStats {
+	return &healthStats{errors: map[string]int64{}}
+}
+
+func (s *healthStats) observe(dur time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[s.next%healthWindow] = dur
+	s.next++
+	s.requests++
+	if err != nil {
+		s.errors[errorKind(err)]++
+	}
+}
+
+func (s *healthStats) observeRateLimit(at time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.lastRateLimit = at
+	s.mu.Unlock()
+}
+
+func (s *healthStats) report() HealthReport {
+	if s == nil {
+		return HealthReport{Errors: map[string]int64{}}
+	}
+	s.mu.Lock()
+	n := s.next
+	if n > healthWindow {
+		n = healthWindow
+	}
+	latencies := append([]time.Duration(nil), s.latencies[:n]...)
+	report := HealthReport{
+		Requests:      s.requests,
+		Errors:        make(map[string]int64, len(s.errors)),
+		LastRateLimit: s.lastRateLimit,
+	}
+	for kind, count := range s.errors {
+		report.Errors[kind] = count
+	}
+	s.mu.Unlock()
+
+	if n > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.P50 = latencies[(n-1)*50/100]
+		report.P95 = latencies[(n-1)*95/100]
+	}
+	return report
+}
+
+// errorKind names err's type for HealthReport.Errors.
+func errorKind(err error) string {
+	var (
+		validation *ValidationError
+		auth       *AuthenticationError
+		forbidden  *ForbiddenError
+		notFound   *NotFoundError
+		conflict   *ConflictError
+		rateLimit  *RateLimitError
+		tooLarge   *PayloadTooLargeError
+		maint      *MaintenanceError
+		server     *ServerError
+		timeout    *TimeoutError
+		network    *NetworkError
+	)
+	switch {
+	case errors.As(err, &validation):
+		return "validation"
+	case errors.As(err, &auth):
+		return "authentication"
+	case errors.As(err, &forbidden):
+		return "forbidden"
+	case errors.As(err, &notFound):
+		return "not_found"
+	case errors.As(err, &conflict):
+		return "conflict"
+	case errors.As(err, &rateLimit):
+		return "rate_limit"
+	case errors.As(err, &tooLarge):
+		return "payload_too_large"
+	case errors.As(err, &maint):
+		return "maintenance"
+	case errors.As(err, &server):
+		return "server"
+	case errors.As(err, &timeout):
+		return "timeout"
+	case errors.As(err, &network):
+		return "network"
+	}
+	return "other"
+}
+
+// HealthReport returns a snapshot of request counts, errors by kind, recent
+// latency percentiles and the last rate limit seen by this client.
+func (c *Client) HealthReport() HealthReport {
+	return c.http.health.report()
+}
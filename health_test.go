@@ -0,0 +1,67 @@
+package proof
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_HealthReport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/verifications/ver_missing":
+			w.WriteHeader(404)
+		case "/api/v1/verifications/ver_limited":
+			w.WriteHeader(429)
+		case "/api/v1/verifications/ver_broken":
+			w.WriteHeader(500)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	if report := client.HealthReport(); report.Requests != 0 || !report.LastRateLimit.IsZero() {
+		t.Fatalf("want empty report before any request, got %+v", report)
+	}
+	for _, id := range []string{"ver_1", "ver_2", "ver_missing", "ver_limited", "ver_broken"} {
+		client.Verifications.Retrieve(context.Background(), id)
+	}
+
+	report := client.HealthReport()
+	if report.Requests != 5 {
+		t.Errorf("want 5 requests, got %d", report.Requests)
+	}
+	want := map[string]int64{"not_found": 1, "rate_limit": 1, "server": 1}
+	if len(report.Errors) != len(want) {
+		t.Errorf("want errors %v, got %v", want, report.Errors)
+	}
+	for kind, n := range want {
+		if report.Errors[kind] != n {
+			t.Errorf("want %d %s errors, got %d", n, kind, report.Errors[kind])
+		}
+	}
+	if report.LastRateLimit.IsZero() {
+		t.Error("want LastRateLimit set after a 429")
+	}
+	if report.P50 <= 0 || report.P95 < report.P50 {
+		t.Errorf("unexpected latencies p50=%s p95=%s", report.P50, report.P95)
+	}
+}
+
+func TestHealthStats_PercentilesOverWindow(t *testing.T) {
+	s := newHealthStats()
+	for i := 1; i <= healthWindow+100; i++ {
+		s.observe(time.Duration(i)*time.Millisecond, nil)
+	}
+	report := s.report()
+	if report.Requests != healthWindow+100 {
+		t.Errorf("want %d requests, got %d", healthWindow+100, report.Requests)
+	}
+	// Only the last healthWindow latencies (101ms-356ms) count.
+	if report.P50 != 228*time.Millisecond || report.P95 != 343*time.Millisecond {
+		t.Errorf("want p50=228ms p95=343ms, got p50=%s p95=%s", report.P50, report.P95)
+	}
+}
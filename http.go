@@ -4,35 +4,53 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 )
 
-const (
-	backoffBaseMs = 1000
-	backoffMaxMs  = 10000
-)
-
 type httpClient struct {
-	apiKey     string
-	baseURL    string
-	timeout    time.Duration
-	maxRetries int
-	client     *http.Client
+	apiKey         string
+	baseURL        string
+	timeout        time.Duration
+	maxRetries     int
+	client         *http.Client
+	retryPredicate RetryPredicate
+	metrics        Metrics
+	retryBackoff   Backoff
+	debug          *debugDumper
+	timestamp      bool
+	clock          clock
+	errorRedactor  ErrorRedactor
+	maxRequestSize int
+	retryConnReset bool
+	noRetries      bool
+	waitDefaults   *WaitOptions
+	etags          *etagCache
+	lastResponse   *responseCapture
+	health         *healthStats
 }
 
 func newHTTPClient(apiKey, baseURL string, timeout time.Duration, maxRetries int) *httpClient {
 	return &httpClient{
-		apiKey:     apiKey,
-		baseURL:    baseURL,
-		timeout:    timeout,
-		maxRetries: maxRetries,
-		client:     &http.Client{Timeout: timeout},
+		apiKey:        apiKey,
+		baseURL:       baseURL,
+		timeout:       timeout,
+		maxRetries:    maxRetries,
+		client:        &http.Client{Timeout: timeout},
+		metrics:       noopMetrics{},
+		retryBackoff:  DefaultBackoff,
+		clock:         realClock{},
+		errorRedactor: DefaultErrorRedactor,
+		health:        newHealthStats(),
 	}
 }
 
@@ -44,109 +62,508 @@ func (h *httpClient) post(ctx context.Context, path string, body any) (map[strin
 	return h.request(ctx, http.MethodPost, path, body, nil)
 }
 
+func (h *httpClient) patch(ctx context.Context, path string, body any) (map[string]any, error) {
+	return h.request(ctx, http.MethodPatch, path, body, nil)
+}
+
+func (h *httpClient) put(ctx context.Context, path string, body any) (map[string]any, error) {
+	return h.request(ctx, http.MethodPut, path, body, nil)
+}
+
 func (h *httpClient) del(ctx context.Context, path string) (map[string]any, error) {
 	return h.request(ctx, http.MethodDelete, path, nil, nil)
 }
 
+// request performs an API call and decodes a JSON object response. A
+// top-level JSON array is returned under the "data" key rather than dropped.
 func (h *httpClient) request(ctx context.Context, method, path string, body any, query url.Values) (map[string]any, error) {
-	u, err := url.Parse(h.baseURL + path)
+	respBody, err := h.send(ctx, method, path, body, query)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			result = nil
+			var items []any
+			if json.Unmarshal(respBody, &items) == nil {
+				result = map[string]any{"data": items}
+			}
+		}
+	}
+	if result == nil {
+		result = make(map[string]any)
+	}
+	return result, nil
+}
+
+// requestRawJSON performs an API call and returns the undecoded response body,
+// for typed decoding of objects and arrays alike.
+func (h *httpClient) requestRawJSON(ctx context.Context, method, path string, body any, query url.Values) (json.RawMessage, error) {
+	respBody, err := h.send(ctx, method, path, body, query)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(respBody), nil
+}
+
+// getStream GETs a list endpoint and hands the response to decodeJSONArray
+// with item and field. When nothing needs the whole body (no ETag cache,
+// response capture or debug dump), it is decoded straight off the connection
+// so a large list is never held in memory at once.
+func (h *httpClient) getStream(ctx context.Context, path string, query url.Values, item func(json.RawMessage) error, field func(string, json.RawMessage) error) error {
+	decode := func(r io.Reader) error {
+		if err := decodeJSONArray(r, item, field); err != nil {
+			return fmt.Errorf("failed to decode list response from GET %s: %w", path, err)
+		}
+		return nil
+	}
+	respBody, err := h.sendStream(ctx, http.MethodGet, path, nil, query, decode)
+	if err != nil || respBody == nil {
+		return err
+	}
+	return decode(bytes.NewReader(respBody))
+}
+
+// streamable reports whether successful bodies may be consumed unbuffered.
+func (h *httpClient) streamable() bool {
+	return h.etags == nil && h.lastResponse == nil && h.debug == nil
+}
+
+// send executes the request with retries and returns the raw body of a
+// successful response. Error statuses are mapped to typed errors.
+func (h *httpClient) send(ctx context.Context, method, path string, body any, query url.Values) ([]byte, error) {
+	return h.sendStream(ctx, method, path, body, query, nil)
+}
+
+// sendStream is send with an optional consume function. If consume is set
+// and the client is streamable, a successful response body is passed to it
+// unread and sendStream returns consume's error with a nil body; otherwise
+// the body is buffered and returned as by send.
+func (h *httpClient) sendStream(ctx context.Context, method, path string, body any, query url.Values, consume func(io.Reader) error) (_ []byte, err error) {
+	start := time.Now()
+	status, attempts := 0, 0
+	defer func() {
+		dur := time.Since(start)
+		h.metrics.ObserveRequest(method, templatePath(path), status, dur, attempts)
+		h.health.observe(dur, err)
+	}()
+
+	baseURL, err := h.baseURLFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateContextHeaders(ctx); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(baseURL + path)
 	if err != nil {
-		return nil, &NetworkError{ProofError{Message: err.Error(), Code: "network_error"}}
+		return nil, newNetworkError(err)
 	}
-	if query != nil {
-		u.RawQuery = query.Encode()
+	var extra url.Values
+	if method == http.MethodGet {
+		extra = rawQueryFromContext(ctx)
+	}
+	if len(query) > 0 || len(extra) > 0 {
+		// A query string already in path (RawRequest) is kept; query
+		// overrides its keys and the context's raw query only adds new ones.
+		merged := u.Query()
+		for k, vs := range query {
+			merged[k] = vs
+		}
+		u.RawQuery = mergeRawQuery(merged, extra).Encode()
+	}
+
+	var ctxTimeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		ctxTimeout = time.Until(deadline)
 	}
 
 	var lastErr error
 
-	for attempt := 0; attempt <= h.maxRetries; attempt++ {
+	maxRetries := h.maxRetries
+	if n, ok := retriesFromContext(ctx); ok {
+		if n < 0 {
+			return nil, fmt.Errorf("retries override must be >= 0, got %d", n)
+		}
+		if !h.noRetries {
+			maxRetries = n
+		}
+	}
+
+	var data []byte
+	if body != nil {
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		if h.maxRequestSize > 0 && len(data) > h.maxRequestSize {
+			return nil, &PayloadTooLargeError{ProofError{
+				Message: fmt.Sprintf("Request body for %s %s is %d bytes, over the %d byte limit", method, path, len(data), h.maxRequestSize),
+				Code:    "payload_too_large",
+			}}
+		}
+	}
+
+	// Every continue below is guarded by a retry limit.
+	for attempt := 0; ; attempt++ {
 		var bodyReader io.Reader
-		if body != nil {
-			data, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
-			}
+		if data != nil {
 			bodyReader = bytes.NewReader(data)
 		}
 
 		req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
 		if err != nil {
-			return nil, &NetworkError{ProofError{Message: err.Error(), Code: "network_error"}}
+			return nil, newNetworkError(err)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+h.apiKey)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "proof-sdk-go/"+Version)
+		h.setHeaders(req)
+		var cached etagEntry
+		conditional := false
+		if h.etags != nil && method == http.MethodGet {
+			if cached, conditional = h.etags.get(u.String()); conditional {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
+		}
 
+		h.debug.request(req, attempt)
 		resp, err := h.client.Do(req)
+		attempts++
 		if err != nil {
+			h.debug.transportError(err, attempt)
 			lastErr = err
 			if ctx.Err() != nil {
-				return nil, &TimeoutError{ProofError{
-					Message: fmt.Sprintf("Request to %s %s timed out", method, path),
-					Code:    "timeout",
-				}}
+				return nil, newTimeoutError(method, path, ctxTimeout, TimeoutSourceContext)
 			}
-			if attempt < h.maxRetries {
-				time.Sleep(h.backoff(attempt))
+			if h.shouldRetry(attempt, maxRetries, nil, err) || h.retryConnectionReset(attempt, maxRetries, method, err) {
+				observeRetry(h.metrics, nil, err)
+				if sleepCtx(ctx, h.backoff(attempt)) != nil {
+					return nil, newTimeoutError(method, path, ctxTimeout, TimeoutSourceContext)
+				}
 				continue
 			}
 			break
 		}
 
-		defer resp.Body.Close()
-		respBody, _ := io.ReadAll(resp.Body)
-
-		// Rate limiting — retry with backoff
-		if resp.StatusCode == http.StatusTooManyRequests && attempt < h.maxRetries {
-			if ra := resp.Header.Get("Retry-After"); ra != "" {
-				if sec, err := strconv.ParseFloat(ra, 64); err == nil {
-					time.Sleep(time.Duration(sec * float64(time.Second)))
-					continue
-				}
-			}
-			time.Sleep(h.backoff(attempt))
-			continue
+		status = resp.StatusCode
+		if status == http.StatusTooManyRequests {
+			h.health.observeRateLimit(h.clock.Now())
 		}
-
-		// Server errors — retry with backoff
-		if resp.StatusCode >= http.StatusInternalServerError && attempt < h.maxRetries {
-			time.Sleep(h.backoff(attempt))
-			continue
+		if consume != nil && status < http.StatusBadRequest && h.streamable() {
+			defer resp.Body.Close()
+			return nil, consume(resp.Body)
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		h.lastResponse.store(respBody)
+		// Re-expose the buffered body so a retry predicate can inspect it.
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if h.debug != nil {
+			h.debug.response(resp, attempt)
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
 		}
 
-		// Parse response
-		var result map[string]any
-		if len(respBody) > 0 {
-			if err := json.Unmarshal(respBody, &result); err != nil {
-				result = make(map[string]any)
+		// Rate limiting and server errors — retry with backoff
+		if h.shouldRetry(attempt, maxRetries, resp, nil) {
+			observeRetry(h.metrics, resp, nil)
+			if sleepCtx(ctx, h.retryDelay(attempt, resp)) != nil {
+				return nil, newTimeoutError(method, path, ctxTimeout, TimeoutSourceContext)
 			}
-		} else {
-			result = make(map[string]any)
+			continue
 		}
 
 		// Error responses
 		if resp.StatusCode >= http.StatusBadRequest {
-			var apiErr *apiErrorBody
-			if errData, ok := result["error"]; ok && errData != nil {
-				if errBytes, err := json.Marshal(errData); err == nil {
-					apiErr = &apiErrorBody{}
-					_ = json.Unmarshal(errBytes, apiErr)
+			apiErr := parseAPIError(respBody)
+			if apiErr == nil && resp.StatusCode >= http.StatusInternalServerError {
+				apiErr = parseUnwrappedServerError(respBody)
+			}
+			if apiErr != nil && apiErr.Details != nil && h.errorRedactor != nil {
+				apiErr.Details = h.errorRedactor(apiErr.Details)
+			}
+			err := errorFromResponse(resp.StatusCode, apiErr)
+			switch e := err.(type) {
+			case *RateLimitError:
+				if e.RetryAfter == nil {
+					e.RetryAfter = retryAfterSeconds(resp)
 				}
+			case *MaintenanceError:
+				h.fillMaintenanceWindow(e, resp)
 			}
-			return nil, errorFromResponse(resp.StatusCode, apiErr)
+			return nil, err
 		}
 
-		return result, nil
+		if conditional && resp.StatusCode == http.StatusNotModified {
+			return cached.body, nil
+		}
+		if err := checkJSONResponse(resp, respBody, method, path); err != nil {
+			return nil, err
+		}
+		if h.etags != nil && method == http.MethodGet {
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				h.etags.put(u.String(), etag, respBody)
+			}
+		}
+		return respBody, nil
 	}
 
+	var netErr net.Error
+	if errors.As(lastErr, &netErr) && netErr.Timeout() {
+		return nil, newTimeoutError(method, path, h.timeout, TimeoutSourceClient)
+	}
 	if lastErr != nil {
-		return nil, &NetworkError{ProofError{Message: lastErr.Error(), Code: "network_error"}}
+		return nil, newNetworkError(lastErr)
 	}
 	return nil, &NetworkError{ProofError{Message: "Network request failed", Code: "network_error"}}
 }
 
+// baseURLFor returns the base URL for a call: the context override set with
+// WithBaseURLOverride if present, otherwise the client's.
+func (h *httpClient) baseURLFor(ctx context.Context) (string, error) {
+	override, ok := baseURLOverrideFromContext(ctx)
+	if !ok {
+		return h.baseURL, nil
+	}
+	return normalizeBaseURL(override)
+}
+
+// validateContextHeaders checks the WithSource label and WithHeader values
+// carried by ctx before setHeaders applies them.
+func validateContextHeaders(ctx context.Context) error {
+	if label, ok := sourceFromContext(ctx); ok {
+		if err := validateSource(label); err != nil {
+			return err
+		}
+	}
+	return validateHeaders(headersFromContext(ctx))
+}
+
+// setHeaders applies the authentication and default headers to req. It runs
+// once per attempt, so the optional timestamp is fresh on every retry.
+func (h *httpClient) setHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "proof-sdk-go/"+Version)
+	if h.timestamp {
+		req.Header.Set("X-Request-Timestamp", strconv.FormatInt(h.clock.Now().Unix(), 10))
+	}
+	if label, ok := sourceFromContext(req.Context()); ok {
+		req.Header.Set("X-Proof-Source", label)
+	}
+	for key, values := range headersFromContext(req.Context()) {
+		if sdkHeaders[key] {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
+
+// maxBodySnippet bounds how much of an unexpected response body is quoted in
+// an error message.
+const maxBodySnippet = 200
+
+// checkJSONResponse rejects a successful response whose body is neither
+// labelled nor parseable as JSON, e.g. an HTML page from a misconfigured proxy.
+// Valid JSON with a generic Content-Type such as text/plain is tolerated.
+func checkJSONResponse(resp *http.Response, body []byte, method, path string) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || json.Valid(trimmed) {
+		return nil
+	}
+	snippet := string(trimmed)
+	if len(snippet) > maxBodySnippet {
+		snippet = snippet[:maxBodySnippet] + "..."
+	}
+	return &ProofError{
+		Message:    fmt.Sprintf("Unexpected non-JSON response (Content-Type %q) from %s %s: %s", contentType, method, path, snippet),
+		Code:       "unexpected_content_type",
+		StatusCode: resp.StatusCode,
+	}
+}
+
+// parseAPIError extracts the {"error": {...}} envelope from an error body.
+// Fields that fail to decode are left empty so defaults apply.
+func parseAPIError(body []byte) *apiErrorBody {
+	var envelope struct {
+		Error *apiErrorBody `json:"error"`
+	}
+	_ = json.Unmarshal(body, &envelope)
+	return envelope.Error
+}
+
+// parseUnwrappedServerError handles 5xx bodies that put "message" or
+// "detail" at the top level instead of inside an "error" envelope, as some
+// proxies and frameworks do. It returns nil when neither is present.
+func parseUnwrappedServerError(body []byte) *apiErrorBody {
+	var flat struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	_ = json.Unmarshal(body, &flat)
+	switch {
+	case flat.Message != "":
+		return &apiErrorBody{Message: flat.Message}
+	case flat.Detail != "":
+		return &apiErrorBody{Message: flat.Detail}
+	}
+	return nil
+}
+
+func newTimeoutError(method, path string, timeout time.Duration, source TimeoutSource) *TimeoutError {
+	msg := fmt.Sprintf("Request to %s %s timed out", method, path)
+	if timeout > 0 {
+		msg += fmt.Sprintf(" after %s (%s timeout)", timeout, source)
+	}
+	return &TimeoutError{
+		ProofError: ProofError{Message: msg, Code: "timeout"},
+		Timeout:    timeout,
+		Source:     source,
+	}
+}
+
+// shouldRetry reports whether a failed attempt should be retried. Successful
+// responses are never retried, and neither are 401 and 403: a rejected key
+// will not be accepted on a later attempt. A 503 maintenance response is not
+// retried either, since the window it announces (see MaintenanceError) is
+// far longer than any backoff. When a RetryPredicate is configured
+// it replaces the default policy (network errors, 429 and 5xx) for everything
+// else, still bounded by maxRetries.
+func (h *httpClient) shouldRetry(attempt, maxRetries int, resp *http.Response, err error) bool {
+	if attempt >= maxRetries {
+		return false
+	}
+	if err == nil && resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if err == nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return false
+	}
+	if err == nil && isMaintenanceResponse(resp) {
+		return false
+	}
+	if h.retryPredicate != nil {
+		return h.retryPredicate(attempt, resp, err)
+	}
+	if err != nil {
+		return isRetryableNetworkError(err)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryConnectionReset reports whether a reset or truncated connection on an
+// idempotent request should be retried under WithRetryOnConnectionReset. It
+// ignores the retry predicate and allows one retry even when maxRetries is 0.
+func (h *httpClient) retryConnectionReset(attempt, maxRetries int, method string, err error) bool {
+	if !h.retryConnReset || attempt >= max(maxRetries, 1) || !isIdempotentMethod(method) {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableNetworkError classifies transport errors. A host that does not
+// resolve will not start resolving on a retry, so it fails fast; transient
+// failures such as connection refused/reset or DNS timeouts are retried.
+func isRetryableNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	return true
+}
+
+// retryDelay returns how long to wait before the next attempt, honoring a
+// numeric Retry-After header on 429 responses.
+func (h *httpClient) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if sec, err := strconv.ParseFloat(ra, 64); err == nil {
+				return time.Duration(sec * float64(time.Second))
+			}
+		}
+		return h.rateLimitBackoff(attempt)
+	}
+	return h.backoff(attempt)
+}
+
+// retryAfterSeconds parses a Retry-After header given in whole seconds, for
+// rate limit errors whose body has no retryAfter field. HTTP dates are not
+// supported; it returns nil when the header is absent or unparseable.
+func retryAfterSeconds(resp *http.Response) *int {
+	sec, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After")))
+	if err != nil || sec < 0 {
+		return nil
+	}
+	return &sec
+}
+
+// isMaintenanceResponse reports whether resp is a 503 with error code
+// "maintenance". The body is restored for later readers.
+func isMaintenanceResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Body == nil {
+		return false
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	apiErr := parseAPIError(body)
+	return apiErr != nil && apiErr.Code == "maintenance"
+}
+
+// fillMaintenanceWindow sets e.EndsAt from the "ends_at" detail, falling back
+// to the Retry-After header given in seconds or as an HTTP date.
+func (h *httpClient) fillMaintenanceWindow(e *MaintenanceError, resp *http.Response) {
+	if e.RetryAfter == nil {
+		e.RetryAfter = retryAfterSeconds(resp)
+	}
+	if details, ok := e.Details.(map[string]any); ok {
+		if endsAt, ok := GetTime(details, "ends_at"); ok {
+			e.EndsAt = endsAt
+			return
+		}
+	}
+	if e.RetryAfter != nil {
+		e.EndsAt = h.clock.Now().Add(time.Duration(*e.RetryAfter) * time.Second)
+	} else if at, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		e.EndsAt = at
+	}
+}
+
+const (
+	// rateLimitJitter is the minimum jitter applied to 429s without a
+	// Retry-After header, so clients limited together do not retry together.
+	rateLimitJitter = 0.5
+	// maxRateLimitDelay caps the wait for such 429s.
+	maxRateLimitDelay = 30 * time.Second
+)
+
+// rateLimitBackoff is the configured backoff with at least rateLimitJitter of
+// jitter, capped at maxRateLimitDelay.
+func (h *httpClient) rateLimitBackoff(attempt int) time.Duration {
+	b := h.retryBackoff
+	if b.Jitter < rateLimitJitter {
+		b.Jitter = rateLimitJitter
+	}
+	if b.Max <= 0 || b.Max > maxRateLimitDelay {
+		b.Max = maxRateLimitDelay
+	}
+	return b.Delay(attempt)
+}
+
 func (h *httpClient) backoff(attempt int) time.Duration {
-	ms := math.Min(backoffBaseMs*math.Pow(2, float64(attempt)), backoffMaxMs)
-	return time.Duration(ms) * time.Millisecond
+	return h.retryBackoff.Delay(attempt)
 }
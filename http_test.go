@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 )
 
 func testServer(handler http.HandlerFunc) (*httptest.Server, *httpClient) {
@@ -27,6 +33,9 @@ func TestHTTPClient_GetSuccess(t *testing.T) {
 		if r.Header.Get("Content-Type") != "application/json" {
 			t.Error("missing content-type header")
 		}
+		if r.Header.Get("Accept") != "application/json" {
+			t.Error("missing accept header")
+		}
 		json.NewEncoder(w).Encode(map[string]any{"id": "ver_123"})
 	})
 	defer srv.Close()
@@ -60,6 +69,105 @@ func TestHTTPClient_PostWithBody(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_PatchAndPutSendBody(t *testing.T) {
+	var methods []string
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Acme" {
+			t.Errorf("%s: want name 'Acme', got %v", r.Method, body["name"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"name": "Acme"})
+	})
+	defer srv.Close()
+
+	body := map[string]string{"name": "Acme"}
+	if _, err := client.patch(context.Background(), "/api/v1/test", body); err != nil {
+		t.Fatalf("patch: unexpected error: %v", err)
+	}
+	if _, err := client.put(context.Background(), "/api/v1/test", body); err != nil {
+		t.Fatalf("put: unexpected error: %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "PATCH" || methods[1] != "PUT" {
+		t.Errorf("want [PATCH PUT], got %v", methods)
+	}
+}
+
+func TestHTTPClient_PatchRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	})
+	defer srv.Close()
+	client.maxRetries = 1
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+
+	if _, err := client.patch(context.Background(), "/api/v1/test", map[string]string{"name": "Acme"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("want PATCH retried once, got %d calls", calls.Load())
+	}
+}
+
+func TestHTTPClient_MaxRequestSize(t *testing.T) {
+	var calls atomic.Int32
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	})
+	defer srv.Close()
+	client.maxRequestSize = 32
+
+	_, err := client.post(context.Background(), "/api/v1/test", map[string]string{"payload": strings.Repeat("x", 64)})
+	var tooLarge *PayloadTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("want PayloadTooLargeError, got %T: %v", err, err)
+	}
+	if tooLarge.StatusCode != 0 || calls.Load() != 0 {
+		t.Errorf("oversized body should fail locally, got status %d after %d calls", tooLarge.StatusCode, calls.Load())
+	}
+
+	if _, err := client.post(context.Background(), "/api/v1/test", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("small body should be sent, got %v", err)
+	}
+}
+
+func TestHTTPClient_413ReturnsPayloadTooLargeError(t *testing.T) {
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(413)
+	})
+	defer srv.Close()
+
+	_, err := client.post(context.Background(), "/api/v1/test", map[string]string{"a": "b"})
+	var tooLarge *PayloadTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.StatusCode != 413 {
+		t.Fatalf("want PayloadTooLargeError with status 413, got %T: %v", err, err)
+	}
+}
+
+func TestHTTPClient_NonJSONSuccessIsError(t *testing.T) {
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>Login required</body></html>"))
+	})
+	defer srv.Close()
+
+	_, err := client.get(context.Background(), "/api/v1/test", nil)
+	var proofErr *ProofError
+	if !errors.As(err, &proofErr) || proofErr.Code != "unexpected_content_type" {
+		t.Fatalf("want unexpected_content_type error, got %T: %v", err, err)
+	}
+	if !strings.Contains(proofErr.Message, "Login required") || !strings.Contains(proofErr.Message, "text/html") {
+		t.Errorf("error should quote the content type and body, got %q", proofErr.Message)
+	}
+}
+
 func TestHTTPClient_400ReturnsValidationError(t *testing.T) {
 	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(400)
@@ -106,6 +214,31 @@ func TestHTTPClient_500ReturnsServerError(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_500UnwrappedMessage(t *testing.T) {
+	for _, body := range []map[string]any{
+		{"message": "database unavailable"},
+		{"detail": "database unavailable"},
+	} {
+		srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(503)
+			json.NewEncoder(w).Encode(body)
+		})
+
+		_, err := client.get(context.Background(), "/test", nil)
+		srv.Close()
+		var sErr *ServerError
+		if !errors.As(err, &sErr) {
+			t.Fatalf("want ServerError, got %T: %v", err, err)
+		}
+		if sErr.Message != "database unavailable" {
+			t.Errorf("body %v: want unwrapped message, got %q", body, sErr.Message)
+		}
+		if sErr.Code != "http_503" {
+			t.Errorf("want default code http_503, got %q", sErr.Code)
+		}
+	}
+}
+
 func TestHTTPClient_RetryOn500(t *testing.T) {
 	var callCount atomic.Int32
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -172,3 +305,426 @@ func TestHTTPClient_Backoff(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClient_AuthErrorsNeverRetried(t *testing.T) {
+	for _, status := range []int{401, 403} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(status)
+		}))
+
+		client := newHTTPClient("pk_test_123", srv.URL, 5e9, 2)
+		client.retryBackoff = Backoff{Base: time.Millisecond}
+		client.retryPredicate = func(int, *http.Response, error) bool { return true }
+		_, err := client.get(context.Background(), "/test", nil)
+		srv.Close()
+
+		if err == nil {
+			t.Fatalf("%d: expected error", status)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("%d: want exactly 1 attempt, got %d", status, calls.Load())
+		}
+	}
+}
+
+func TestHTTPClient_RetryPredicate(t *testing.T) {
+	codes := map[string]string{
+		"/temporary": "temporarily_unavailable",
+		"/quota":     "quota_exceeded",
+	}
+	calls := map[string]*atomic.Int32{"/temporary": {}, "/quota": {}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls[r.URL.Path].Add(1) > 1 {
+			json.NewEncoder(w).Encode(map[string]any{"ok": true})
+			return
+		}
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": codes[r.URL.Path], "message": "Slow down"},
+		})
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 5e9, 2)
+	client.retryPredicate = func(attempt int, resp *http.Response, err error) bool {
+		if resp == nil {
+			return false
+		}
+		var body struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return body.Error.Code == "temporarily_unavailable"
+	}
+
+	result, err := client.get(context.Background(), "/temporary", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["ok"] != true {
+		t.Errorf("want ok=true, got %v", result["ok"])
+	}
+	if calls["/temporary"].Load() != 2 {
+		t.Errorf("want 2 calls, got %d", calls["/temporary"].Load())
+	}
+
+	_, err = client.get(context.Background(), "/quota", nil)
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("want RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.Code != "quota_exceeded" {
+		t.Errorf("want code 'quota_exceeded', got %q", rlErr.Code)
+	}
+	if calls["/quota"].Load() != 1 {
+		t.Errorf("want 1 call, got %d", calls["/quota"].Load())
+	}
+}
+
+func TestHTTPClient_TimeoutFromClientConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 50*time.Millisecond, 0)
+	_, err := client.get(context.Background(), "/test", nil)
+	var tErr *TimeoutError
+	if !errors.As(err, &tErr) {
+		t.Fatalf("want TimeoutError, got %T: %v", err, err)
+	}
+	if tErr.Source != TimeoutSourceClient {
+		t.Errorf("want source %q, got %q", TimeoutSourceClient, tErr.Source)
+	}
+	if tErr.Timeout != 50*time.Millisecond {
+		t.Errorf("want timeout 50ms, got %v", tErr.Timeout)
+	}
+}
+
+func TestHTTPClient_TimeoutFromContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 5*time.Second, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.get(ctx, "/test", nil)
+	var tErr *TimeoutError
+	if !errors.As(err, &tErr) {
+		t.Fatalf("want TimeoutError, got %T: %v", err, err)
+	}
+	if tErr.Source != TimeoutSourceContext {
+		t.Errorf("want source %q, got %q", TimeoutSourceContext, tErr.Source)
+	}
+	if tErr.Timeout <= 0 || tErr.Timeout > 50*time.Millisecond {
+		t.Errorf("want timeout in (0, 50ms], got %v", tErr.Timeout)
+	}
+}
+
+func TestHTTPClient_ArrayResponseViaRequest(t *testing.T) {
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"a"},{"id":"b"}]`))
+	})
+	defer srv.Close()
+
+	result, err := client.get(context.Background(), "/api/v1/test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items, ok := result["data"].([]any)
+	if !ok || len(items) != 2 {
+		t.Fatalf("want 2 items under 'data', got %v", result)
+	}
+	if items[1].(map[string]any)["id"] != "b" {
+		t.Errorf("want second id 'b', got %v", items[1])
+	}
+}
+
+func TestHTTPClient_RequestRawJSON(t *testing.T) {
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"a"},{"id":"b"}]`))
+	})
+	defer srv.Close()
+
+	raw, err := client.requestRawJSON(context.Background(), http.MethodGet, "/api/v1/test", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var items []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != "a" || items[1].ID != "b" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestHTTPClient_DNSNotFoundFailsFast(t *testing.T) {
+	var callCount atomic.Int32
+	client := newHTTPClient("pk_test_123", "https://api.proof.invalid", 5e9, 2)
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	client.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		callCount.Add(1)
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "api.proof.invalid", IsNotFound: true}}
+	})
+
+	_, err := client.get(context.Background(), "/test", nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("want NetworkError, got %T: %v", err, err)
+	}
+	if callCount.Load() != 1 {
+		t.Errorf("want 1 call (no retry on DNS not found), got %d", callCount.Load())
+	}
+}
+
+func TestHTTPClient_ConnectionRefusedRetried(t *testing.T) {
+	var callCount atomic.Int32
+	client := newHTTPClient("pk_test_123", "https://api.proof.holdings", 5e9, 2)
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	client.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if callCount.Add(1) == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+			Request:    r,
+		}, nil
+	})
+
+	result, err := client.get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["ok"] != true {
+		t.Errorf("want ok=true, got %v", result["ok"])
+	}
+	if callCount.Load() != 2 {
+		t.Errorf("want 2 calls, got %d", callCount.Load())
+	}
+}
+
+func TestHTTPClient_RetryOnConnectionReset(t *testing.T) {
+	var callCount atomic.Int32
+	client := newHTTPClient("pk_test_123", "https://api.proof.holdings", 5e9, 0)
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	client.retryConnReset = true
+	client.retryPredicate = func(int, *http.Response, error) bool { return false }
+	client.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if callCount.Add(1) == 1 {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+			Request:    r,
+		}, nil
+	})
+
+	if _, err := client.get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if callCount.Load() != 2 {
+		t.Errorf("want reset GET retried once, got %d calls", callCount.Load())
+	}
+
+	callCount.Store(0)
+	if _, err := client.post(context.Background(), "/test", nil); err == nil {
+		t.Fatal("want reset POST to fail without retry")
+	}
+	if callCount.Load() != 1 {
+		t.Errorf("want POST attempted once, got %d calls", callCount.Load())
+	}
+}
+
+func TestHTTPClient_ConditionalRequests(t *testing.T) {
+	var ifNoneMatch []string
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "pending"})
+	})
+	defer srv.Close()
+	client.etags = newETagCache()
+
+	first, err := client.get(context.Background(), "/api/v1/verifications/ver_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.get(context.Background(), "/api/v1/verifications/ver_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error on 304: %v", err)
+	}
+	if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("want If-None-Match only on the repeat GET, got %q", ifNoneMatch)
+	}
+	if second["status"] != "pending" || second["id"] != first["id"] {
+		t.Errorf("want cached body on 304, got %v", second)
+	}
+}
+
+func TestHTTPClient_RequestTimestampPerAttempt(t *testing.T) {
+	var timestamps []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamps = append(timestamps, r.Header.Get("X-Request-Timestamp"))
+		if len(timestamps) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	}))
+	defer srv.Close()
+
+	client := newHTTPClient("pk_test_123", srv.URL, 5e9, 1)
+	client.timestamp = true
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	clock := newFakeClock()
+	clock.step = time.Second
+	client.clock = clock
+
+	if _, err := client.get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(timestamps) != 2 {
+		t.Fatalf("want 2 attempts, got %d", len(timestamps))
+	}
+	if timestamps[0] != "1700000001" || timestamps[1] != "1700000002" {
+		t.Errorf("want fresh timestamps per attempt, got %v", timestamps)
+	}
+}
+
+func TestHTTPClient_RequestTimestampDisabledByDefault(t *testing.T) {
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["X-Request-Timestamp"]; ok {
+			t.Error("X-Request-Timestamp should not be sent by default")
+		}
+		json.NewEncoder(w).Encode(map[string]any{})
+	})
+	defer srv.Close()
+
+	if _, err := client.get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPClient_503Maintenance(t *testing.T) {
+	endsAt := "2026-10-16T04:00:00Z"
+	srv, client := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1800")
+		w.WriteHeader(503)
+		details := map[string]any{}
+		if r.URL.Path == "/with-end" {
+			details["ends_at"] = endsAt
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "maintenance", "message": "Scheduled maintenance", "details": details},
+		})
+	})
+	defer srv.Close()
+	now := time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC)
+	client.clock = &fakeClock{now: now}
+
+	_, err := client.get(context.Background(), "/with-end", nil)
+	var mErr *MaintenanceError
+	if !errors.As(err, &mErr) {
+		t.Fatalf("want MaintenanceError, got %T: %v", err, err)
+	}
+	if want, _ := time.Parse(time.RFC3339, endsAt); !mErr.EndsAt.Equal(want) {
+		t.Errorf("want EndsAt %v from details, got %v", want, mErr.EndsAt)
+	}
+	if mErr.RetryAfter == nil || *mErr.RetryAfter != 1800 {
+		t.Errorf("want RetryAfter 1800, got %v", mErr.RetryAfter)
+	}
+	var sErr *ServerError
+	if !errors.As(err, &sErr) {
+		t.Error("MaintenanceError should unwrap to ServerError")
+	}
+
+	_, err = client.get(context.Background(), "/without-end", nil)
+	if !errors.As(err, &mErr) {
+		t.Fatalf("want MaintenanceError, got %T: %v", err, err)
+	}
+	if want := now.Add(30 * time.Minute); !mErr.EndsAt.Equal(want) {
+		t.Errorf("want EndsAt %v from Retry-After, got %v", want, mErr.EndsAt)
+	}
+}
+
+func TestHTTPClient_503MaintenanceNotRetried(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		w.Header().Set("Retry-After", "1800")
+		w.WriteHeader(503)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "maintenance", "message": "Scheduled maintenance"}})
+	}))
+	defer srv.Close()
+	client := newHTTPClient("pk_test_123", srv.URL, 5e9, 3)
+	client.retryBackoff = Backoff{Base: time.Millisecond}
+	client.retryPredicate = func(int, *http.Response, error) bool { return true }
+
+	_, err := client.get(context.Background(), "/test", nil)
+	var mErr *MaintenanceError
+	if !errors.As(err, &mErr) {
+		t.Fatalf("want MaintenanceError, got %T: %v", err, err)
+	}
+	if callCount.Load() != 1 {
+		t.Errorf("want a maintenance 503 attempted once, got %d attempts", callCount.Load())
+	}
+}
+
+func TestHTTPClient_RetryWaitsRespectContext(t *testing.T) {
+	tests := map[string]roundTripFunc{
+		"backoff after network error": func(r *http.Request) (*http.Response, error) {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		},
+		"Retry-After on 429": func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": {"60"}},
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+				Request:    r,
+			}, nil
+		},
+	}
+	for name, transport := range tests {
+		client := newHTTPClient("pk_test_123", "https://api.proof.test", 5*time.Second, 3)
+		client.retryBackoff = Backoff{Base: time.Minute}
+		client.client.Transport = transport
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := client.get(ctx, "/test", nil)
+		cancel()
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: cancellation not honoured promptly: %s", name, elapsed)
+		}
+		var tErr *TimeoutError
+		if !errors.As(err, &tErr) || tErr.Source != TimeoutSourceContext {
+			t.Errorf("%s: want context TimeoutError, got %T: %v", name, err, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package proof
+
+import (
+	"context"
+	"time"
+)
+
+// The Client's resource fields are typed as these interfaces so that code
+// using the client can substitute fakes in tests. Each is implemented by the
+// corresponding concrete type, which NewClient installs.
+
+// VerificationsAPI is the method set of *Verifications.
+type VerificationsAPI interface {
+	Create(ctx context.Context, params map[string]any) (map[string]any, error)
+	Retrieve(ctx context.Context, id string) (map[string]any, error)
+	Update(ctx context.Context, id string, params map[string]any) (map[string]any, error)
+	List(ctx context.Context, params map[string]string) (map[string]any, error)
+	ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error)
+	ListByExternalUser(ctx context.Context, externalUserID string, extra map[string]string) (map[string]any, error)
+	Verify(ctx context.Context, id string) (map[string]any, error)
+	Submit(ctx context.Context, id, code string) (map[string]any, error)
+	Resend(ctx context.Context, id string) (map[string]any, error)
+	ResendTyped(ctx context.Context, id string) (*ResendResult, error)
+	ListDeliveries(ctx context.Context, id string) ([]DeliveryAttempt, error)
+	TestVerify(ctx context.Context, id string) (map[string]any, error)
+	ListVerifiedUsers(ctx context.Context, params map[string]string) (map[string]any, error)
+	GetVerifiedUser(ctx context.Context, externalUserID string) (map[string]any, error)
+	ListVerifiedUsersSince(ctx context.Context, since time.Time) ([]VerifiedUser, error)
+	StartDomainVerification(ctx context.Context, params map[string]any) (map[string]any, error)
+	CheckDomainVerification(ctx context.Context, id string) (map[string]any, error)
+	WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error)
+	WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error)
+	Watch(ctx context.Context, id string, cb func(status string, resource map[string]any), opts *WaitOptions) (map[string]any, error)
+	WaitForProof(ctx context.Context, id string, opts *WaitOptions) (string, map[string]any, error)
+	WaitForVerifiedProof(ctx context.Context, id string, opts *WaitOptions) (*VerifiedProof, error)
+	Stream(ctx context.Context, id string) (<-chan StatusEvent, error)
+}
+
+// VerificationRequestsAPI is the method set of *VerificationRequests.
+type VerificationRequestsAPI interface {
+	Create(ctx context.Context, params map[string]any) (map[string]any, error)
+	Retrieve(ctx context.Context, id string) (map[string]any, error)
+	List(ctx context.Context, params map[string]string) (map[string]any, error)
+	ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error)
+	GetByReference(ctx context.Context, referenceID string) (map[string]any, error)
+	GetByReferenceOrNil(ctx context.Context, referenceID string) (map[string]any, bool, error)
+	EnsureByReference(ctx context.Context, referenceID string, params map[string]any) (map[string]any, bool, error)
+	Cancel(ctx context.Context, id string) (map[string]any, error)
+	WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error)
+	WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error)
+	CreateAndWait(ctx context.Context, params map[string]any, opts *WaitOptions) (map[string]any, error)
+}
+
+// ProofsAPI is the method set of *Proofs.
+type ProofsAPI interface {
+	Validate(ctx context.Context, proofToken string, identifier string) (map[string]any, error)
+	ValidateTyped(ctx context.Context, proofToken string, identifier string) (*ValidationResult, error)
+	ValidateBound(ctx context.Context, proofToken string, expectedIdentifier string) (*ValidationResult, error)
+	Revoke(ctx context.Context, id string, reason string) (map[string]any, error)
+	RevokeBatch(ctx context.Context, ids []string, reason string) ([]BatchResult, error)
+	RevokeWithReason(ctx context.Context, id string, reason RevokeReason) (map[string]any, error)
+	Status(ctx context.Context, id string) (map[string]any, error)
+	ListRevoked(ctx context.Context) (map[string]any, error)
+	VerifyOffline(ctx context.Context, proofToken string) (ProofClaims, error)
+	DecodeUnverified(proofToken string) (ProofClaims, error)
+}
+
+// SessionsAPI is the method set of *Sessions.
+type SessionsAPI interface {
+	Create(ctx context.Context, params map[string]any) (map[string]any, error)
+	Retrieve(ctx context.Context, id string) (map[string]any, error)
+	WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error)
+	WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error)
+}
+
+// WebhookDeliveriesAPI is the method set of *WebhookDeliveries.
+type WebhookDeliveriesAPI interface {
+	Stats(ctx context.Context) (map[string]any, error)
+	StatsTyped(ctx context.Context, params *StatsParams) (*WebhookStats, error)
+	List(ctx context.Context, params map[string]string) (map[string]any, error)
+	ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error)
+	ListByVerification(ctx context.Context, verificationID string) ([]WebhookDelivery, error)
+	Retrieve(ctx context.Context, id string) (map[string]any, error)
+	Retry(ctx context.Context, id string) (map[string]any, error)
+	SendTest(ctx context.Context, params map[string]any) (map[string]any, error)
+}
@@ -0,0 +1,43 @@
+package proof
+
+import (
+	"context"
+	"testing"
+)
+
+var (
+	_ VerificationsAPI        = (*Verifications)(nil)
+	_ VerificationRequestsAPI = (*VerificationRequests)(nil)
+	_ ProofsAPI               = (*Proofs)(nil)
+	_ SessionsAPI             = (*Sessions)(nil)
+	_ WebhookDeliveriesAPI    = (*WebhookDeliveries)(nil)
+)
+
+// fakeSessions overrides one method; the embedded interface panics on the rest.
+type fakeSessions struct {
+	SessionsAPI
+}
+
+func (fakeSessions) Retrieve(ctx context.Context, id string) (map[string]any, error) {
+	return map[string]any{"id": id, "status": "verified"}, nil
+}
+
+func TestClient_ResourceFieldsAcceptFakes(t *testing.T) {
+	client, _ := NewClient("pk_test_123")
+	client.Sessions = fakeSessions{}
+
+	result, err := client.Sessions.Retrieve(context.Background(), "ses_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "verified" {
+		t.Errorf("want fake result, got %v", result)
+	}
+}
+
+func TestClient_CloseWithoutNewClient(t *testing.T) {
+	client := &Client{Sessions: fakeSessions{}}
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
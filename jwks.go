@@ -0,0 +1,288 @@
+package proof
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultJWKSTTL is how long fetched signing keys are trusted before the
+	// JWKS endpoint is consulted again.
+	defaultJWKSTTL = 10 * time.Minute
+	// minJWKSRefetch limits refetches triggered by unknown key IDs, so tokens
+	// with made-up kids cannot hammer the endpoint.
+	minJWKSRefetch = time.Minute
+)
+
+// InvalidProofError is returned by Proofs.VerifyOffline when a proof token's
+// signature, algorithm, signing key or expiry does not check out.
+type InvalidProofError struct{ ProofError }
+
+func newInvalidProofError(format string, args ...any) *InvalidProofError {
+	return &InvalidProofError{ProofError{Message: fmt.Sprintf(format, args...), Code: "invalid_proof"}}
+}
+
+// jwksCache holds the API's proof signing keys by key ID. Keys are refetched
+// once the TTL has passed or when a token names an unknown key; if a refetch
+// fails, the previous keys stay in use. Fetches run without mu held, so
+// lookups of known keys never wait on the network.
+type jwksCache struct {
+	http *httpClient
+	url  string
+	ttl  time.Duration
+
+	// fetchMu serializes fetches; mu guards keys and fetchedAt only.
+	fetchMu   sync.Mutex
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+
+	// Background refresh, see startRefresher.
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newJWKSCache(h *httpClient, url string) *jwksCache {
+	return &jwksCache{http: h, url: url, ttl: defaultJWKSTTL}
+}
+
+// key returns the public key for kid, fetching the key set when needed.
+func (c *jwksCache) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	fetchedAt := c.fetchedAt
+	age := c.http.clock.Now().Sub(fetchedAt)
+	fresh := c.keys != nil && age < c.ttl
+	c.mu.Unlock()
+	if ok && fresh {
+		return key, nil
+	}
+	if !fresh || age >= minJWKSRefetch {
+		if ok {
+			// A stale key is still usable; don't queue behind a fetch in flight.
+			if !c.fetchMu.TryLock() {
+				return key, nil
+			}
+		} else {
+			c.fetchMu.Lock()
+		}
+		err := c.refreshFetchLocked(ctx, fetchedAt)
+		c.fetchMu.Unlock()
+		if err != nil && !ok {
+			return nil, err
+		}
+	}
+	c.mu.Lock()
+	key, ok = c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	return nil, newInvalidProofError("proof token signed with unknown key %q", kid)
+}
+
+// startRefresher refreshes the keys on a goroutine shortly before each TTL
+// expiry, so verification never waits on a fetch. A failed refresh keeps the
+// previous keys and is retried at the next interval. Call stop to end it.
+func (c *jwksCache) startRefresher() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	interval := c.ttl - c.ttl/10
+	go func() {
+		defer close(c.done)
+		for {
+			_ = c.refresh(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.http.clock.After(interval):
+			}
+		}
+	}()
+}
+
+// stop ends the background refresher, if running, and waits for it to exit.
+// It is safe to call more than once, and on a nil cache.
+func (c *jwksCache) stop() {
+	if c == nil {
+		return
+	}
+	c.stopOnce.Do(func() {
+		if c.cancel != nil {
+			c.cancel()
+			<-c.done
+		}
+	})
+}
+
+// refresh fetches the key set unconditionally.
+func (c *jwksCache) refresh(ctx context.Context) error {
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	c.mu.Lock()
+	seen := c.fetchedAt
+	c.mu.Unlock()
+	return c.refreshFetchLocked(ctx, seen)
+}
+
+// refreshFetchLocked fetches the key set and swaps it in, unless another
+// fetch has completed since seen. The caller holds fetchMu, not mu.
+func (c *jwksCache) refreshFetchLocked(ctx context.Context, seen time.Time) error {
+	c.mu.Lock()
+	refreshed := !c.fetchedAt.Equal(seen)
+	c.mu.Unlock()
+	if refreshed {
+		return nil
+	}
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = c.http.clock.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// fetch downloads and parses the key set. The endpoint is public, so no
+// credentials are sent.
+func (c *jwksCache) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "proof-sdk-go/"+Version)
+	resp, err := c.http.client.Do(req)
+	if err != nil {
+		return nil, newNetworkError(fmt.Errorf("failed to fetch JWKS: %w", err))
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp.StatusCode, parseAPIError(body))
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		// Keys of unsupported types are skipped rather than failing the set.
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	return keys, nil
+}
+
+// jwk is a single JSON Web Key; only RSA and P-256 EC keys are supported.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("EC key %q is not on P-256", k.Kid)
+		}
+		return pub, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// verifyJWT checks the signature of a compact JWT with the key named by its
+// "kid" header. Only RS256 and ES256 are accepted.
+func (c *jwksCache) verifyJWT(ctx context.Context, token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return newInvalidProofError("malformed proof token: expected 3 dot-separated segments")
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return newInvalidProofError("malformed proof token header: %v", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return newInvalidProofError("malformed proof token header: %v", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return newInvalidProofError("malformed proof token signature: %v", err)
+	}
+	if header.Alg != "RS256" && header.Alg != "ES256" {
+		return newInvalidProofError("unsupported proof token algorithm %q", header.Alg)
+	}
+
+	key, err := c.key(ctx, header.Kid)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if header.Alg == "RS256" && rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if header.Alg == "ES256" && len(sig) == 64 {
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			if ecdsa.Verify(pub, digest[:], r, s) {
+				return nil
+			}
+		}
+	}
+	return newInvalidProofError("proof token signature is invalid")
+}
@@ -0,0 +1,345 @@
+package proof
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSigner holds an ES256 and an RS256 key and serves them as a JWKS.
+type testSigner struct {
+	ec  *ecdsa.PrivateKey
+	rsa *rsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSigner{ec: ecKey, rsa: rsaKey}
+}
+
+func (s *testSigner) jwks() map[string]any {
+	enc := base64.RawURLEncoding
+	pad := func(b []byte) []byte { return append(make([]byte, 32-len(b)), b...) }
+	return map[string]any{"keys": []map[string]any{
+		{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": enc.EncodeToString(pad(s.ec.X.Bytes())), "y": enc.EncodeToString(pad(s.ec.Y.Bytes()))},
+		{"kid": "rsa-1", "kty": "RSA", "n": enc.EncodeToString(s.rsa.N.Bytes()), "e": enc.EncodeToString(big.NewInt(int64(s.rsa.E)).Bytes())},
+	}}
+}
+
+// sign returns a compact JWT over claims signed with the key for alg.
+func (s *testSigner) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var sig []byte
+	switch alg {
+	case "ES256":
+		r, sv, err := ecdsa.Sign(rand.Reader, s.ec, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		sv.FillBytes(sig[32:])
+	case "RS256":
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, s.rsa, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signingInput + "." + enc.EncodeToString(sig)
+}
+
+// jwksServer serves signer's keys at the JWKS path and counts fetches; other
+// paths are handled by api, if set.
+func jwksServer(t *testing.T, signer *testSigner, fetches *atomic.Int32, api http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/jwks.json" {
+			if fetches != nil {
+				fetches.Add(1)
+			}
+			json.NewEncoder(w).Encode(signer.jwks())
+			return
+		}
+		if api != nil {
+			api(w, r)
+		}
+	}))
+}
+
+func TestProofs_VerifyOffline(t *testing.T) {
+	signer := newTestSigner(t)
+	var fetches atomic.Int32
+	srv := jwksServer(t, signer, &fetches, nil)
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	exp := time.Now().Add(time.Hour).Unix()
+	for _, alg := range []string{"ES256", "RS256"} {
+		kid := map[string]string{"ES256": "ec-1", "RS256": "rsa-1"}[alg]
+		token := signer.sign(t, alg, kid, map[string]any{"sub": "ver_1", "type": "phone", "exp": exp})
+		claims, err := client.Proofs.VerifyOffline(context.Background(), token)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", alg, err)
+		}
+		if claims.Subject != "ver_1" || claims.Type != "phone" {
+			t.Errorf("%s: unexpected claims %+v", alg, claims)
+		}
+	}
+	if fetches.Load() != 1 {
+		t.Errorf("want keys fetched once and cached, got %d fetches", fetches.Load())
+	}
+}
+
+func TestProofs_VerifyOfflineRejects(t *testing.T) {
+	signer := newTestSigner(t)
+	srv := jwksServer(t, signer, nil, nil)
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	valid := signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1", "exp": time.Now().Add(time.Hour).Unix()})
+	tokens := map[string]string{
+		"tampered":    valid[:len(valid)-4] + "AAAA",
+		"expired":     signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"unknown kid": signer.sign(t, "ES256", "ec-9", map[string]any{"sub": "ver_1"}),
+		"wrong key":   signer.sign(t, "RS256", "ec-1", map[string]any{"sub": "ver_1"}),
+		"no exp":      signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1"}),
+		"alg none":    base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + ".e30.",
+		"malformed":   "not-a-token",
+	}
+	for name, token := range tokens {
+		_, err := client.Proofs.VerifyOffline(context.Background(), token)
+		var invalid *InvalidProofError
+		if !errors.As(err, &invalid) {
+			t.Errorf("%s: want InvalidProofError, got %T: %v", name, err, err)
+		}
+	}
+}
+
+func TestVerifications_WaitForVerifiedProof(t *testing.T) {
+	signer := newTestSigner(t)
+	token := signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1", "verification_id": "ver_1", "exp": time.Now().Add(time.Hour).Unix()})
+	srv := jwksServer(t, signer, nil, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified", "proof_token": token})
+	})
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	proof, err := client.Verifications.WaitForVerifiedProof(context.Background(), "ver_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proof.Token != token || proof.Claims.VerificationID != "ver_1" || proof.Resource["status"] != "verified" {
+		t.Errorf("unexpected verified proof: %+v", proof)
+	}
+}
+
+func TestVerifications_WaitForVerifiedProofSubjectOnly(t *testing.T) {
+	signer := newTestSigner(t)
+	token := signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1", "exp": time.Now().Add(time.Hour).Unix()})
+	srv := jwksServer(t, signer, nil, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified", "proof_token": token})
+	})
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	proof, err := client.Verifications.WaitForVerifiedProof(context.Background(), "ver_1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proof.Claims.Subject != "ver_1" {
+		t.Errorf("unexpected claims: %+v", proof.Claims)
+	}
+}
+
+func TestVerifications_WaitForVerifiedProofOtherVerification(t *testing.T) {
+	signer := newTestSigner(t)
+	token := signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_other", "verification_id": "ver_other", "exp": time.Now().Add(time.Hour).Unix()})
+	srv := jwksServer(t, signer, nil, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified", "proof_token": token})
+	})
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, err := client.Verifications.WaitForVerifiedProof(context.Background(), "ver_1", nil)
+	var mismatch *IdentifierMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("want IdentifierMismatchError, got %T: %v", err, err)
+	}
+	if mismatch.Expected != "ver_1" || mismatch.Actual != "ver_other" {
+		t.Errorf("unexpected mismatch details %+v", mismatch)
+	}
+}
+
+func TestJWKSCache_BackgroundRefresh(t *testing.T) {
+	signer := newTestSigner(t)
+	var fetches atomic.Int32
+	srv := jwksServer(t, signer, &fetches, nil)
+	defer srv.Close()
+
+	h := newHTTPClient("pk_test_123", srv.URL, 5*time.Second, 0)
+	cache := newJWKSCache(h, srv.URL+"/.well-known/jwks.json")
+	cache.ttl = 20 * time.Millisecond
+	cache.startRefresher()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for fetches.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if fetches.Load() < 3 {
+		t.Fatalf("want keys refreshed in the background, got %d fetches", fetches.Load())
+	}
+
+	cache.stop()
+	cache.stop()
+	stopped := fetches.Load()
+	time.Sleep(60 * time.Millisecond)
+	if fetches.Load() != stopped {
+		t.Errorf("refresher kept running after stop: %d -> %d fetches", stopped, fetches.Load())
+	}
+	if _, err := cache.key(context.Background(), "ec-1"); err != nil {
+		t.Errorf("refreshed keys not usable: %v", err)
+	}
+}
+
+func TestJWKSCache_BackgroundRefreshKeepsStaleKeys(t *testing.T) {
+	signer := newTestSigner(t)
+	var fail atomic.Bool
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(signer.jwks())
+	}))
+	defer srv.Close()
+
+	h := newHTTPClient("pk_test_123", srv.URL, 5*time.Second, 0)
+	cache := newJWKSCache(h, srv.URL+"/.well-known/jwks.json")
+	cache.ttl = 20 * time.Millisecond
+	cache.startRefresher()
+	defer cache.stop()
+
+	for fetches.Load() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	fail.Store(true)
+	for n := fetches.Load(); fetches.Load() < n+2; {
+		time.Sleep(time.Millisecond)
+	}
+	cache.mu.Lock()
+	_, ok := cache.keys["ec-1"]
+	cache.mu.Unlock()
+	if !ok {
+		t.Error("failed background refresh discarded the previous keys")
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	signer := newTestSigner(t)
+	var fetches atomic.Int32
+	srv := jwksServer(t, signer, &fetches, nil)
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithJWKSBackgroundRefresh(true))
+	deadline := time.Now().Add(2 * time.Second)
+	for fetches.Load() < 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if fetches.Load() != 1 {
+		t.Fatalf("want an initial background fetch, got %d", fetches.Load())
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case <-client.jwks.done:
+	default:
+		t.Error("refresher goroutine still running after Close")
+	}
+
+	plain, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	if err := plain.Close(); err != nil {
+		t.Errorf("Close without background work: %v", err)
+	}
+}
+
+func TestJWKSCache_VerifyDuringStalledRefresh(t *testing.T) {
+	signer := newTestSigner(t)
+	var fetches atomic.Int32
+	stalled := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			close(stalled)
+			<-release
+		}
+		json.NewEncoder(w).Encode(signer.jwks())
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	h := newHTTPClient("pk_test_123", srv.URL, 5*time.Second, 0)
+	cache := newJWKSCache(h, srv.URL+"/.well-known/jwks.json")
+	token := signer.sign(t, "ES256", "ec-1", map[string]any{"sub": "ver_1"})
+	if err := cache.verifyJWT(context.Background(), token); err != nil {
+		t.Fatalf("initial verify: %v", err)
+	}
+
+	go cache.refresh(context.Background())
+	<-stalled
+
+	done := make(chan error, 1)
+	go func() { done <- cache.verifyJWT(context.Background(), token) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("verify during refresh: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("verification blocked behind a stalled JWKS refresh")
+	}
+
+	// A stale key is also served while the refresh is still stalled.
+	cache.mu.Lock()
+	cache.fetchedAt = cache.fetchedAt.Add(-2 * cache.ttl)
+	cache.mu.Unlock()
+	go func() { done <- cache.verifyJWT(context.Background(), token) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("verify with stale keys during refresh: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stale-key verification blocked behind a stalled JWKS refresh")
+	}
+}
@@ -0,0 +1,175 @@
+package proof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// SortOrder is the direction of a sorted listing.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// ListOptions are typed list filters. Sort names a column (e.g. "created_at")
+// and Order its direction, encoded as sort=created_at&order=desc; each list
+// method documents the columns it accepts. Filters carries any other
+// parameters as-is; empty values are omitted.
+type ListOptions struct {
+	Limit   int
+	Sort    string
+	Order   SortOrder
+	Filters map[string]string
+}
+
+// queryFromMap is how every map-based filter becomes a query: empty values
+// are dropped, so an all-empty filter sends no query string at all rather
+// than e.g. "?status=", which some servers treat differently from absent.
+func queryFromMap(params map[string]string) url.Values {
+	q := url.Values{}
+	for k, val := range params {
+		if val != "" {
+			q.Set(k, val)
+		}
+	}
+	return q
+}
+
+// requireFilter rejects an empty value for a filter a method is scoped by.
+// queryFromMap would drop it, silently widening the call to every record.
+func requireFilter(name, value string) error {
+	if value == "" {
+		return &ValidationError{ProofError{
+			Message: name + " must not be empty",
+			Code:    "missing_filter",
+		}}
+	}
+	return nil
+}
+
+// query encodes opts, rejecting a Sort column outside allowedSort and an
+// unknown Order with a *ValidationError. opts may be nil.
+func (opts *ListOptions) query(allowedSort ...string) (url.Values, error) {
+	q := url.Values{}
+	if opts == nil {
+		return q, nil
+	}
+	for k, vs := range queryFromMap(opts.Filters) {
+		q[k] = vs
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Sort != "" {
+		if !containsString(allowedSort, opts.Sort) {
+			return nil, &ValidationError{ProofError{
+				Message: fmt.Sprintf("cannot sort by %q; allowed columns: %v", opts.Sort, allowedSort),
+				Code:    "invalid_sort",
+			}}
+		}
+		q.Set("sort", opts.Sort)
+	}
+	switch opts.Order {
+	case "":
+	case SortAsc, SortDesc:
+		q.Set("order", string(opts.Order))
+	default:
+		return nil, &ValidationError{ProofError{
+			Message: fmt.Sprintf("invalid sort order %q: use %q or %q", opts.Order, SortAsc, SortDesc),
+			Code:    "invalid_sort",
+		}}
+	}
+	return q, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeJSONArray decodes a list response item by item, calling fn with each
+// raw item, so large lists need not be materialized at once. It accepts a
+// top-level array or an object whose "data" field is the array; the object's
+// other fields, before or after "data", go to field if it is non-nil and are
+// skipped otherwise. An empty body or a missing or null "data" yields no
+// items. An error from fn or field stops decoding and is returned as is.
+func decodeJSONArray(r io.Reader, fn func(json.RawMessage) error, field func(key string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('['):
+		return decodeArrayItems(dec, fn)
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if key == "data" {
+				if err := decodeDataField(dec, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			if field != nil {
+				if err := field(key.(string), value); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := dec.Token()
+		return err
+	}
+	return fmt.Errorf("expected a JSON array or object, got %v", tok)
+}
+
+// decodeDataField decodes the value of a "data" key: an array or null.
+func decodeDataField(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return errors.New(`"data" is not an array`)
+	}
+	return decodeArrayItems(dec, fn)
+}
+
+// decodeArrayItems decodes the remaining items of an array whose opening
+// bracket dec has already consumed.
+func decodeArrayItems(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	for dec.More() {
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
@@ -0,0 +1,283 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListOptions_Query(t *testing.T) {
+	tests := []struct {
+		opts *ListOptions
+		want string
+	}{
+		{nil, ""},
+		{&ListOptions{Sort: "created_at", Order: SortAsc}, "order=asc&sort=created_at"},
+		{&ListOptions{Sort: "created_at", Order: SortDesc, Limit: 10}, "limit=10&order=desc&sort=created_at"},
+		{&ListOptions{Filters: map[string]string{"status": "verified", "type": ""}}, "status=verified"},
+	}
+	for _, tt := range tests {
+		q, err := tt.opts.query("created_at")
+		if err != nil {
+			t.Fatalf("%+v: unexpected error: %v", tt.opts, err)
+		}
+		if got := q.Encode(); got != tt.want {
+			t.Errorf("%+v: want %q, got %q", tt.opts, tt.want, got)
+		}
+	}
+}
+
+func TestListOptions_QueryRejectsInvalid(t *testing.T) {
+	for _, opts := range []*ListOptions{{Sort: "password"}, {Order: "sideways"}} {
+		_, err := opts.query("created_at")
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Errorf("%+v: want ValidationError, got %v", opts, err)
+		}
+	}
+}
+
+func TestVerifications_ListWithOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/verifications" || r.URL.RawQuery != "order=desc&sort=created_at&status=pending" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, err := client.Verifications.ListWithOptions(context.Background(), &ListOptions{
+		Sort:    "created_at",
+		Order:   SortDesc,
+		Filters: map[string]string{"status": "pending"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerificationRequests_ListWithOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/verification-requests" || r.URL.RawQuery != "order=asc&sort=updated_at&status=completed" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, err := client.VerificationRequests.ListWithOptions(context.Background(), &ListOptions{
+		Sort:    "updated_at",
+		Order:   SortAsc,
+		Filters: map[string]string{"status": "completed"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestList_EmptyFiltersOmitQuery(t *testing.T) {
+	var uris []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uris = append(uris, r.RequestURI)
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	empty := map[string]string{"status": "", "type": ""}
+	ctx := WithRawQuery(context.Background(), url.Values{"region": {""}})
+	calls := []func() error{
+		func() error { _, err := client.Verifications.List(ctx, empty); return err },
+		func() error { _, err := client.Verifications.ListVerifiedUsers(ctx, empty); return err },
+		func() error { _, err := client.VerificationRequests.List(ctx, empty); return err },
+		func() error { _, err := client.WebhookDeliveries.List(ctx, empty); return err },
+		func() error {
+			_, err := client.Verifications.ListWithOptions(ctx, &ListOptions{Filters: empty})
+			return err
+		},
+	}
+	for _, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for _, uri := range uris {
+		if strings.Contains(uri, "?") {
+			t.Errorf("all-empty filter should send no query string, got %s", uri)
+		}
+	}
+}
+
+func TestDecodeJSONArray_Incremental(t *testing.T) {
+	const total = 5000
+	pr, pw := io.Pipe()
+	firstSeen := make(chan struct{})
+	go func() {
+		pw.Write([]byte(`{"pagination":{"has_more":false},"data":[{"id":"item_0"}`))
+		// The rest is only written once the first item has been handed to the
+		// callback, so a decoder that buffers the whole body would deadlock.
+		select {
+		case <-firstSeen:
+		case <-time.After(2 * time.Second):
+		}
+		for i := 1; i < total; i++ {
+			fmt.Fprintf(pw, `,{"id":"item_%d","padding":"%s"}`, i, strings.Repeat("x", 100))
+		}
+		pw.Write([]byte(`]}`))
+		pw.Close()
+	}()
+
+	count := 0
+	err := decodeJSONArray(pr, func(item json.RawMessage) error {
+		var v struct{ ID string }
+		if err := json.Unmarshal(item, &v); err != nil {
+			return err
+		}
+		if want := fmt.Sprintf("item_%d", count); v.ID != want {
+			t.Fatalf("want %s, got %s", want, v.ID)
+		}
+		if count == 0 {
+			close(firstSeen)
+		}
+		count++
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-firstSeen:
+	default:
+		t.Fatal("callback never saw the first item")
+	}
+	if count != total {
+		t.Errorf("want %d items, got %d", total, count)
+	}
+}
+
+func TestDecodeJSONArray_Shapes(t *testing.T) {
+	tests := map[string]struct {
+		body    string
+		want    int
+		wantErr bool
+	}{
+		"array":       {body: `[1,2,3]`, want: 3},
+		"envelope":    {body: `{"data":[1,2],"total":2}`, want: 2},
+		"null data":   {body: `{"data":null}`},
+		"no data":     {body: `{"total":0}`},
+		"empty body":  {body: ``},
+		"data scalar": {body: `{"data":1}`, wantErr: true},
+		"truncated":   {body: `[1,2`, wantErr: true},
+		"scalar":      {body: `42`, wantErr: true},
+	}
+	for name, tt := range tests {
+		got := 0
+		err := decodeJSONArray(strings.NewReader(tt.body), func(json.RawMessage) error { got++; return nil }, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tt.wantErr)
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s: want %d items, got %d", name, tt.want, got)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := decodeJSONArray(strings.NewReader(`[1,2,3]`), func(json.RawMessage) error { calls++; return stop }, nil)
+	if err != stop || calls != 1 {
+		t.Errorf("want callback error to stop decoding after 1 item, got %v after %d", err, calls)
+	}
+}
+
+func TestDecodeJSONArray_EnvelopeFields(t *testing.T) {
+	fields := map[string]string{}
+	items := 0
+	err := decodeJSONArray(strings.NewReader(`{"meta":{"v":1},"data":[1,2],"pagination":{"has_more":true}}`),
+		func(json.RawMessage) error { items++; return nil },
+		func(key string, value json.RawMessage) error { fields[key] = string(value); return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items != 2 || fields["meta"] != `{"v":1}` || fields["pagination"] != `{"has_more":true}` {
+		t.Errorf("want 2 items and both fields around data, got %d items, fields %v", items, fields)
+	}
+}
+
+// guardedBody serves first, then fails any further read until released,
+// proving the consumer did not buffer past the first item.
+type guardedBody struct {
+	first    []byte
+	rest     *strings.Reader
+	released *bool
+}
+
+func (b *guardedBody) Read(p []byte) (int, error) {
+	if len(b.first) > 0 {
+		n := copy(p, b.first)
+		b.first = b.first[n:]
+		return n, nil
+	}
+	if !*b.released {
+		return 0, errors.New("body read past the first item before it was handled")
+	}
+	return b.rest.Read(p)
+}
+
+func (b *guardedBody) Close() error { return nil }
+
+func TestHTTPClient_GetStreamDoesNotBuffer(t *testing.T) {
+	released := false
+	h := newHTTPClient("pk_test_123", "https://api.proof.test", 5*time.Second, 0)
+	h.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body: &guardedBody{
+				first:    []byte(`{"data":[{"id":"item_0"}`),
+				rest:     strings.NewReader(`,{"id":"item_1"}],"pagination":{"has_more":false}}`),
+				released: &released,
+			},
+			Request: r,
+		}, nil
+	})
+
+	var ids []string
+	err := h.getStream(context.Background(), "/api/v1/items", nil, func(item json.RawMessage) error {
+		var v struct{ ID string }
+		json.Unmarshal(item, &v)
+		ids = append(ids, v.ID)
+		released = true
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ids, ",") != "item_0,item_1" {
+		t.Errorf("unexpected items %v", ids)
+	}
+
+	// With response capture on, the buffered body is decoded instead.
+	released = true
+	ids = nil
+	h.lastResponse = &responseCapture{}
+	err = h.getStream(context.Background(), "/api/v1/items", nil, func(item json.RawMessage) error {
+		ids = append(ids, string(item))
+		return nil
+	}, nil)
+	if err != nil || len(ids) != 2 {
+		t.Errorf("want 2 items from the buffered path, got %v, %v", ids, err)
+	}
+	if len(h.lastResponse.load()) == 0 {
+		t.Error("want the buffered body captured")
+	}
+}
@@ -0,0 +1,62 @@
+package proof
+
+import (
+	"net/http"
+	"time"
+)
+
+// Metrics receives per-request observations, e.g. to feed Prometheus or StatsD.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called once per logical request after the final attempt.
+	// path is templated (IDs replaced by placeholders) to keep label cardinality
+	// low, and is "other" for paths the SDK has no route for. status is 0 when
+	// no response was received, and attempts counts every HTTP attempt
+	// including retries.
+	ObserveRequest(method, path string, status int, dur time.Duration, attempts int)
+}
+
+// RetryMetrics is an optional extension of Metrics. If the configured Metrics
+// also implements it, RetryOccurred is called before every retry with the
+// reason: RetryReasonRateLimited, RetryReasonServerError, RetryReasonNetwork or
+// RetryReasonOther (a custom RetryPredicate retrying another status).
+type RetryMetrics interface {
+	RetryOccurred(reason string)
+}
+
+// Retry reasons reported to RetryMetrics.
+const (
+	RetryReasonRateLimited = "rate_limited"
+	RetryReasonServerError = "server_error"
+	RetryReasonNetwork     = "network"
+	RetryReasonOther       = "other"
+)
+
+// retryReason classifies why an attempt is being retried.
+func retryReason(resp *http.Response, err error) string {
+	switch {
+	case err != nil:
+		return RetryReasonNetwork
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return RetryReasonRateLimited
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return RetryReasonServerError
+	}
+	return RetryReasonOther
+}
+
+// observeRetry reports a retry to m if it implements RetryMetrics.
+func observeRetry(m Metrics, resp *http.Response, err error) {
+	if rm, ok := m.(RetryMetrics); ok {
+		rm.RetryOccurred(retryReason(resp, err))
+	}
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(string, string, int, time.Duration, int) {}
+
+// WithMetrics sets a Metrics sink that observes every request.
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *clientConfig) { c.metrics = m }
+}
@@ -0,0 +1,139 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	method   string
+	path     string
+	status   int
+	dur      time.Duration
+	attempts int
+}
+
+type fakeMetrics struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (m *fakeMetrics) ObserveRequest(method, path string, status int, dur time.Duration, attempts int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, observation{method, path, status, dur, attempts})
+}
+
+type fakeRetryMetrics struct {
+	fakeMetrics
+	reasons []string
+}
+
+func (m *fakeRetryMetrics) RetryOccurred(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reasons = append(m.reasons, reason)
+}
+
+func TestMetrics_RetryOccurred(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if callCount.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1"})
+	}))
+	defer srv.Close()
+
+	m := &fakeRetryMetrics{}
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMetrics(m))
+	if _, err := client.Verifications.Retrieve(context.Background(), "ver_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.reasons) != 1 || m.reasons[0] != RetryReasonRateLimited {
+		t.Errorf("want one rate_limited retry, got %v", m.reasons)
+	}
+}
+
+func TestRetryReason(t *testing.T) {
+	tests := []struct {
+		resp *http.Response
+		err  error
+		want string
+	}{
+		{nil, io.ErrUnexpectedEOF, RetryReasonNetwork},
+		{&http.Response{StatusCode: 429}, nil, RetryReasonRateLimited},
+		{&http.Response{StatusCode: 503}, nil, RetryReasonServerError},
+		{&http.Response{StatusCode: 409}, nil, RetryReasonOther},
+	}
+	for _, tt := range tests {
+		if got := retryReason(tt.resp, tt.err); got != tt.want {
+			t.Errorf("want %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestMetrics_ObserveRequest(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if callCount.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			json.NewEncoder(w).Encode(map[string]any{})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_123", "status": "verified"})
+	}))
+	defer srv.Close()
+
+	metrics := &fakeMetrics{}
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(1), WithMetrics(metrics))
+	if _, err := client.Verifications.Submit(context.Background(), "ver_123", "ABC123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(metrics.observations) != 1 {
+		t.Fatalf("want 1 observation, got %d", len(metrics.observations))
+	}
+	got := metrics.observations[0]
+	if got.method != "POST" {
+		t.Errorf("want method POST, got %q", got.method)
+	}
+	if got.path != "/api/v1/verifications/{id}/submit" {
+		t.Errorf("want templated path, got %q", got.path)
+	}
+	if got.status != 200 {
+		t.Errorf("want status 200, got %d", got.status)
+	}
+	if got.attempts != 2 {
+		t.Errorf("want 2 attempts, got %d", got.attempts)
+	}
+	if got.dur <= 0 {
+		t.Errorf("want positive duration, got %v", got.dur)
+	}
+}
+
+func TestMetrics_RawRequestUnknownRoute(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "obj_123"})
+	}))
+	defer srv.Close()
+
+	metrics := &fakeMetrics{}
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMetrics(metrics))
+	if _, err := client.RawRequest(context.Background(), http.MethodGet, "/api/v1/new-endpoint/obj_123?limit=10", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics.observations) != 1 || metrics.observations[0].path != "other" {
+		t.Errorf("want one observation labelled other, got %+v", metrics.observations)
+	}
+}
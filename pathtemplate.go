@@ -0,0 +1,101 @@
+package proof
+
+import "strings"
+
+// routeTemplates lists the API routes with variable segments. Any segment in
+// braces matches a single concrete segment; when several routes match, the
+// one with the most literal segments wins, so "/verifications/users" is not
+// reported as "/verifications/{id}".
+var routeTemplates = []string{
+	"/api/v1/verifications",
+	"/api/v1/verifications/{id}",
+	"/api/v1/verifications/{id}/verify",
+	"/api/v1/verifications/{id}/submit",
+	"/api/v1/verifications/{id}/resend",
+	"/api/v1/verifications/{id}/test-verify",
+	"/api/v1/verifications/{id}/stream",
+	"/api/v1/verifications/{id}/deliveries",
+	"/api/v1/verifications/users",
+	"/api/v1/verifications/users/{id}",
+	"/api/v1/verifications/domain",
+	"/api/v1/verifications/domain/{id}/check",
+	"/api/v1/verification-requests",
+	"/api/v1/verification-requests/{id}",
+	"/api/v1/verification-requests/by-reference/{id}",
+	"/api/v1/proofs/validate",
+	"/api/v1/proofs/revoked",
+	"/api/v1/proofs/{id}/revoke",
+	"/api/v1/proofs/{id}/status",
+	"/api/v1/sessions",
+	"/api/v1/sessions/{id}",
+	"/api/v1/webhook-deliveries",
+	"/api/v1/webhook-deliveries/stats",
+	"/api/v1/webhook-deliveries/test",
+	"/api/v1/webhook-deliveries/{id}",
+	"/api/v1/webhook-deliveries/{id}/retry",
+	"/api/v1/templates/defaults",
+	"/api/v1/templates/{channel}/{message_type}",
+	"/api/v1/templates/{channel}/{message_type}/render",
+	"/api/v1/projects/{id}",
+	"/api/v1/projects/{id}/templates",
+	"/api/v1/projects/{id}/templates/{channel}/{message_type}",
+}
+
+// unmatchedRoute is the label templatePath gives paths with no known route.
+const unmatchedRoute = "other"
+
+// templatePath maps a concrete request path to its route template for use as
+// a low-cardinality observability label, e.g.
+// /api/v1/verifications/ver_123/submit becomes /api/v1/verifications/{id}/submit.
+// Query strings are dropped. Paths matching no route, such as most RawRequest
+// paths, are reported as unmatchedRoute so their IDs never become labels.
+func templatePath(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	best, bestLiterals := unmatchedRoute, -1
+	for _, route := range routeTemplates {
+		routeSegments := strings.Split(route, "/")
+		if len(routeSegments) != len(segments) {
+			continue
+		}
+		literals := 0
+		matched := true
+		for i, rs := range routeSegments {
+			if isPlaceholder(rs) {
+				if segments[i] == "" {
+					matched = false
+					break
+				}
+				continue
+			}
+			if rs != segments[i] {
+				matched = false
+				break
+			}
+			literals++
+		}
+		if matched && literals > bestLiterals {
+			best, bestLiterals = route, literals
+		}
+	}
+	return best
+}
+
+func isPlaceholder(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// requireSegment rejects an empty value for a required path segment, which
+// would otherwise produce a malformed path such as /verifications//submit or
+// silently hit the collection endpoint.
+func requireSegment(name, value string) error {
+	if value == "" {
+		return &ValidationError{ProofError{
+			Message: name + " must not be empty",
+			Code:    "invalid_path_segment",
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package proof
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTemplatePath(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/api/v1/verifications", "/api/v1/verifications"},
+		{"/api/v1/verifications/ver_123", "/api/v1/verifications/{id}"},
+		{"/api/v1/verifications/ver_123/submit", "/api/v1/verifications/{id}/submit"},
+		{"/api/v1/verifications/ver_123/test-verify", "/api/v1/verifications/{id}/test-verify"},
+		{"/api/v1/verifications/users", "/api/v1/verifications/users"},
+		{"/api/v1/verifications/users/ext_1", "/api/v1/verifications/users/{id}"},
+		{"/api/v1/verifications/domain/ver_9/check", "/api/v1/verifications/domain/{id}/check"},
+		{"/api/v1/verification-requests/by-reference/order-42", "/api/v1/verification-requests/by-reference/{id}"},
+		{"/api/v1/proofs/revoked", "/api/v1/proofs/revoked"},
+		{"/api/v1/proofs/ver_1/revoke", "/api/v1/proofs/{id}/revoke"},
+		{"/api/v1/sessions/ses_1?expand=true", "/api/v1/sessions/{id}"},
+		{"/api/v1/webhook-deliveries/stats", "/api/v1/webhook-deliveries/stats"},
+		{"/api/v1/webhook-deliveries/del_1/retry", "/api/v1/webhook-deliveries/{id}/retry"},
+		{"/api/v1/templates/defaults", "/api/v1/templates/defaults"},
+		{"/api/v1/templates/sms/otp", "/api/v1/templates/{channel}/{message_type}"},
+		{"/api/v1/templates/email/magic_link/render", "/api/v1/templates/{channel}/{message_type}/render"},
+		{"/api/v1/projects/prj_1/templates", "/api/v1/projects/{id}/templates"},
+		{"/api/v1/projects/prj_1/templates/whatsapp/otp", "/api/v1/projects/{id}/templates/{channel}/{message_type}"},
+		{"/api/v1/verification-requests?status=pending", "/api/v1/verification-requests"},
+		{"/api/v1/verifications/", "other"},
+		{"/unknown/path", "other"},
+		{"/api/v1/new-endpoint/obj_123", "other"},
+	}
+	for _, tt := range tests {
+		if got := templatePath(tt.in); got != tt.want {
+			t.Errorf("templatePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEmptyPathSegmentsRejected(t *testing.T) {
+	var calls int
+	client, _ := NewClient("pk_test_123", WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	})}))
+	ctx := context.Background()
+
+	methods := map[string]func() error{
+		"Verifications.Retrieve": func() error { _, err := client.Verifications.Retrieve(ctx, ""); return err },
+		"Verifications.Submit":   func() error { _, err := client.Verifications.Submit(ctx, "", "123456"); return err },
+		"Verifications.GetVerifiedUser": func() error {
+			_, err := client.Verifications.GetVerifiedUser(ctx, "")
+			return err
+		},
+		"VerificationRequests.Cancel": func() error { _, err := client.VerificationRequests.Cancel(ctx, ""); return err },
+		"Proofs.Revoke":               func() error { _, err := client.Proofs.Revoke(ctx, "", ""); return err },
+		"Sessions.Retrieve":           func() error { _, err := client.Sessions.Retrieve(ctx, ""); return err },
+		"WebhookDeliveries.Retry":     func() error { _, err := client.WebhookDeliveries.Retry(ctx, ""); return err },
+	}
+	for name, call := range methods {
+		err := call()
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || !strings.Contains(valErr.Message, "must not be empty") {
+			t.Errorf("%s: want empty segment ValidationError, got %v", name, err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("want no requests for empty segments, got %d", calls)
+	}
+}
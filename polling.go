@@ -2,37 +2,89 @@ package proof
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
 
 // pollUntilComplete is a generic polling helper. It calls retrieve repeatedly
 // until the returned map's "status" field matches a terminal state, or the
-// timeout is reached. Context cancellation is respected between polls.
+// timeout is reached. The first retrieve happens immediately unless
+// opts.InitialDelay is set. Context cancellation is respected between polls.
 func pollUntilComplete(
 	ctx context.Context,
+	clk clock,
 	retrieve func(context.Context) (map[string]any, error),
 	isTerminal func(string) bool,
 	label string,
 	opts *WaitOptions,
 ) (map[string]any, error) {
+	return pollUntil(ctx, clk, retrieve, func(resource map[string]any) (bool, string) {
+		status, _ := resource["status"].(string)
+		return isTerminal(status), "last status: " + status
+	}, label, opts)
+}
+
+// pollUntil drives pollUntilComplete. done reports whether the resource is
+// complete and describes its state for the timeout message. All waiting goes
+// through clk, normally the client's real clock.
+func pollUntil(
+	ctx context.Context,
+	clk clock,
+	retrieve func(context.Context) (map[string]any, error),
+	done func(map[string]any) (bool, string),
+	label string,
+	opts *WaitOptions,
+) (map[string]any, error) {
+	if err := validateWaitOptions(opts); err != nil {
+		return nil, err
+	}
 	interval, timeout := resolveWaitOptions(opts)
-	start := time.Now()
+	start := clk.Now()
 
-	for {
+	if opts != nil && opts.InitialDelay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-clk.After(opts.InitialDelay):
+		}
+	}
+
+	for poll := 0; ; poll++ {
 		resource, err := retrieve(ctx)
 		if err != nil {
+			var notFound *NotFoundError
+			if poll > 0 && errors.As(err, &notFound) {
+				return nil, &PollingTerminatedError{
+					ProofError: ProofError{
+						Message:    fmt.Sprintf("%s disappeared while polling (deleted after %d successful polls)", label, poll),
+						Code:       "polling_terminated",
+						StatusCode: notFound.StatusCode,
+						RequestID:  notFound.RequestID,
+					},
+					Err: err,
+				}
+			}
 			return nil, err
 		}
 
-		status, _ := resource["status"].(string)
-		if isTerminal(status) {
+		if opts != nil && opts.OnPoll != nil {
+			opts.OnPoll(poll, resource)
+		}
+		complete, state := done(resource)
+		if complete {
 			return resource, nil
 		}
 
-		if time.Since(start) >= timeout {
+		if clk.Now().Sub(start) >= timeout {
+			return nil, &PollingTimeoutError{ProofError{
+				Message: fmt.Sprintf("%s did not complete within %s (%s)", label, timeout, state),
+				Code:    "polling_timeout",
+			}}
+		}
+		if opts != nil && opts.MaxPolls > 0 && poll+1 >= opts.MaxPolls {
 			return nil, &PollingTimeoutError{ProofError{
-				Message: fmt.Sprintf("%s did not complete within %s (last status: %s)", label, timeout, status),
+				Message: fmt.Sprintf("%s did not complete within %d polls (%s)", label, opts.MaxPolls, state),
 				Code:    "polling_timeout",
 			}}
 		}
@@ -40,7 +92,102 @@ func pollUntilComplete(
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-time.After(interval):
+		case <-clk.After(pollDelay(opts, interval, poll)):
+		}
+	}
+}
+
+// waitWithStream follows the SSE status stream at streamPath when
+// opts.PreferStream is set and resumes with pollUntilComplete, within the same
+// overall timeout, if the stream is unavailable or ends early. Without
+// PreferStream, or with DisableStream, it simply polls.
+func waitWithStream(
+	ctx context.Context,
+	h *httpClient,
+	streamPath string,
+	retrieve func(context.Context) (map[string]any, error),
+	isTerminal func(string) bool,
+	label string,
+	opts *WaitOptions,
+) (map[string]any, error) {
+	if opts == nil || !opts.PreferStream || opts.DisableStream {
+		return pollUntilComplete(ctx, h.clock, retrieve, isTerminal, label, opts)
+	}
+	if err := validateWaitOptions(opts); err != nil {
+		return nil, err
+	}
+	_, timeout := resolveWaitOptions(opts)
+	start := h.clock.Now()
+
+	streamCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if resp, err := h.openEventStream(streamCtx, streamPath); err == nil && resp != nil {
+		var last map[string]any
+		terminal, _ := readStatusStream(resp.Body, isTerminal, func(ev StatusEvent) bool {
+			last = ev.Resource
+			return true
+		})
+		resp.Body.Close()
+		if terminal {
+			return last, nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Poll for whatever time the stream left; at least one poll is made.
+	pollOpts := *opts
+	pollOpts.InitialDelay = 0
+	pollOpts.Timeout = timeout - h.clock.Now().Sub(start)
+	if pollOpts.Timeout <= 0 {
+		pollOpts.Timeout = time.Nanosecond
+	}
+	if pollOpts.Interval > pollOpts.Timeout {
+		pollOpts.Interval = pollOpts.Timeout
+	}
+	return pollUntilComplete(ctx, h.clock, retrieve, isTerminal, label, &pollOpts)
+}
+
+// NextPollDelay returns how long to wait after the given zero-based poll
+// before retrieving again, following the same schedule as WaitForCompletion
+// (fixed Interval, or Backoff when set). It is meant for callers that drive
+// polling from their own scheduler. ok is false when lastStatus is terminal
+// for any resource type (verification, session or verification request), in
+// which case no further poll is needed. opts may be nil for the defaults.
+func (opts *WaitOptions) NextPollDelay(attempt int, lastStatus string) (delay time.Duration, ok bool) {
+	if isTerminalVerificationStatus(lastStatus) || isTerminalSessionStatus(lastStatus) || isTerminalRequestStatus(lastStatus) {
+		return 0, false
+	}
+	interval, _ := resolveWaitOptions(opts)
+	return pollDelay(opts, interval, attempt), true
+}
+
+// pollDelay returns the wait after the given zero-based poll: the fixed
+// interval, or the backoff schedule when opts.Backoff is set.
+func pollDelay(opts *WaitOptions, interval time.Duration, poll int) time.Duration {
+	if opts != nil && opts.Backoff != nil {
+		return opts.Backoff.Delay(poll)
+	}
+	return interval
+}
+
+// idEnvelopes lists the wrapper keys create endpoints may nest the created
+// resource under, checked in order after the top-level "id".
+var idEnvelopes = []string{"data", "verification", "session", "verification_request"}
+
+// extractID returns the resource ID from a create response, accepting both
+// flat ({"id": ...}) and enveloped ({"data": {"id": ...}}) shapes.
+func extractID(resource map[string]any) (string, bool) {
+	if id, ok := resource["id"].(string); ok && id != "" {
+		return id, true
+	}
+	for _, key := range idEnvelopes {
+		if nested, ok := resource[key].(map[string]any); ok {
+			if id, ok := nested["id"].(string); ok && id != "" {
+				return id, true
+			}
 		}
 	}
+	return "", false
 }
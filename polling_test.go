@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -65,14 +66,19 @@ func TestPolling_Timeout(t *testing.T) {
 	defer srv.Close()
 
 	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	client.http.clock = newFakeClock()
+	start := time.Now()
 	_, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
-		Interval: 10 * time.Millisecond,
-		Timeout:  50 * time.Millisecond,
+		Interval: time.Minute,
+		Timeout:  5 * time.Minute,
 	})
 	var pollErr *PollingTimeoutError
 	if !errors.As(err, &pollErr) {
 		t.Fatalf("want PollingTimeoutError, got %T: %v", err, err)
 	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("fake clock should make the timeout instant, took %s", elapsed)
+	}
 }
 
 func TestPolling_ContextCancellation(t *testing.T) {
@@ -128,3 +134,475 @@ func TestPolling_RequestTerminalStates(t *testing.T) {
 		t.Error("'pending' should not be terminal")
 	}
 }
+
+func TestStatusConstants(t *testing.T) {
+	tests := []struct {
+		got, want                 string
+		wantTerminal, gotTerminal bool
+	}{
+		{string(VerificationStatusPending), "pending", false, VerificationStatusPending.IsTerminal()},
+		{string(VerificationStatusVerified), "verified", true, VerificationStatusVerified.IsTerminal()},
+		{string(VerificationStatusFailed), "failed", true, VerificationStatusFailed.IsTerminal()},
+		{string(VerificationStatusExpired), "expired", true, VerificationStatusExpired.IsTerminal()},
+		{string(VerificationStatusRevoked), "revoked", true, VerificationStatusRevoked.IsTerminal()},
+		{string(SessionStatusPending), "pending", false, SessionStatusPending.IsTerminal()},
+		{string(SessionStatusVerified), "verified", true, SessionStatusVerified.IsTerminal()},
+		{string(SessionStatusFailed), "failed", true, SessionStatusFailed.IsTerminal()},
+		{string(SessionStatusExpired), "expired", true, SessionStatusExpired.IsTerminal()},
+		{string(VerificationRequestStatusPending), "pending", false, VerificationRequestStatusPending.IsTerminal()},
+		{string(VerificationRequestStatusCompleted), "completed", true, VerificationRequestStatusCompleted.IsTerminal()},
+		{string(VerificationRequestStatusExpired), "expired", true, VerificationRequestStatusExpired.IsTerminal()},
+		{string(VerificationRequestStatusCancelled), "cancelled", true, VerificationRequestStatusCancelled.IsTerminal()},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("want constant %q, got %q", tt.want, tt.got)
+		}
+		if tt.gotTerminal != tt.wantTerminal {
+			t.Errorf("%q: want IsTerminal %t, got %t", tt.want, tt.wantTerminal, tt.gotTerminal)
+		}
+	}
+	if SessionStatus("revoked").IsTerminal() {
+		t.Error("sessions have no revoked status")
+	}
+}
+
+func TestExtractID(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     map[string]any
+		want   string
+		wantOK bool
+	}{
+		{"flat", map[string]any{"id": "ver_1", "status": "pending"}, "ver_1", true},
+		{"nested data", map[string]any{"data": map[string]any{"id": "vr_2"}}, "vr_2", true},
+		{"nested session", map[string]any{"session": map[string]any{"id": "ses_3"}}, "ses_3", true},
+		{"flat wins", map[string]any{"id": "ver_1", "data": map[string]any{"id": "other"}}, "ver_1", true},
+		{"missing", map[string]any{"status": "pending"}, "", false},
+		{"empty id", map[string]any{"id": ""}, "", false},
+		{"wrong type", map[string]any{"id": 42}, "", false},
+		{"nil map", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractID(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("extractID() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPolling_BackoffSchedule(t *testing.T) {
+	opts := &WaitOptions{Interval: time.Second, Backoff: &Backoff{Base: 10 * time.Millisecond, Factor: 2, Max: 30 * time.Millisecond}}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	for poll, w := range want {
+		if got := pollDelay(opts, time.Second, poll); got != w {
+			t.Errorf("poll %d: want %v, got %v", poll, w, got)
+		}
+	}
+	if got := pollDelay(&WaitOptions{}, time.Second, 3); got != time.Second {
+		t.Errorf("without backoff: want fixed 1s interval, got %v", got)
+	}
+}
+
+func TestPolling_PreferStreamTerminal(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifications/ver_1/stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("data: {\"id\":\"ver_1\",\"status\":\"pending\"}\n\ndata: {\"id\":\"ver_1\",\"status\":\"verified\"}\n\n"))
+			return
+		}
+		polls.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	result, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval:     10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		PreferStream: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "verified" {
+		t.Errorf("want 'verified', got %v", result["status"])
+	}
+	if polls.Load() != 0 {
+		t.Errorf("want no polls when the stream completes, got %d", polls.Load())
+	}
+}
+
+func TestPolling_PreferStreamDropFallsBackToPolling(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifications/ver_1/stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("data: {\"id\":\"ver_1\",\"status\":\"pending\"}\n\n"))
+			return // connection drops before a terminal status
+		}
+		status := "pending"
+		if polls.Add(1) >= 2 {
+			status = "verified"
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": status})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	result, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval:     10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		PreferStream: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "verified" {
+		t.Errorf("want 'verified', got %v", result["status"])
+	}
+	if polls.Load() != 2 {
+		t.Errorf("want 2 polls after the stream dropped, got %d", polls.Load())
+	}
+}
+
+func TestPolling_PreferStreamUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifications/ver_1/stream" {
+			w.WriteHeader(404)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "failed"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	result, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval:     10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		PreferStream: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "failed" {
+		t.Errorf("want 'failed', got %v", result["status"])
+	}
+}
+
+func TestPolling_IntervalExceedsTimeout(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval: time.Second,
+		Timeout:  100 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("expected configuration error when Interval exceeds Timeout")
+	}
+	if callCount.Load() != 0 {
+		t.Errorf("want no requests for invalid options, got %d", callCount.Load())
+	}
+}
+
+func TestPolling_ResourceDeletedMidPoll(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if callCount.Add(1) == 1 {
+			json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "pending"})
+			return
+		}
+		w.WriteHeader(404)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "not_found", "message": "Not found"}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  5 * time.Second,
+	})
+	var terminated *PollingTerminatedError
+	if !errors.As(err, &terminated) {
+		t.Fatalf("want PollingTerminatedError, got %T: %v", err, err)
+	}
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Error("PollingTerminatedError should unwrap to the NotFoundError")
+	}
+}
+
+func TestPolling_NotFoundOnFirstPoll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.WaitForCompletion(context.Background(), "ver_missing", nil)
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("want NotFoundError, got %T: %v", err, err)
+	}
+	var terminated *PollingTerminatedError
+	if errors.As(err, &terminated) {
+		t.Error("a resource that never existed should not be reported as terminated")
+	}
+}
+
+func TestPolling_NegativeWaitOptions(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"id": "x_1", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	waits := map[string]func(context.Context, string, *WaitOptions) (map[string]any, error){
+		"verifications":         client.Verifications.WaitForCompletion,
+		"sessions":              client.Sessions.WaitForCompletion,
+		"verification requests": client.VerificationRequests.WaitForCompletion,
+	}
+	for name, wait := range waits {
+		for _, opts := range []*WaitOptions{{Interval: -time.Second}, {Timeout: -time.Second}} {
+			_, err := wait(context.Background(), "x_1", opts)
+			if err == nil || !strings.Contains(err.Error(), "must not be negative") {
+				t.Errorf("%s %+v: want negative duration error, got %v", name, *opts, err)
+			}
+		}
+	}
+	if callCount.Load() != 0 {
+		t.Errorf("want no requests for invalid options, got %d", callCount.Load())
+	}
+}
+
+func TestPolling_ClientDefaultWaitOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "ses_1", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0),
+		WithDefaultWaitOptions(WaitOptions{Interval: time.Millisecond, Timeout: 30 * time.Millisecond}))
+	start := time.Now()
+	_, err := client.Sessions.WaitForCompletion(context.Background(), "ses_1", nil)
+	var pollErr *PollingTimeoutError
+	if !errors.As(err, &pollErr) {
+		t.Fatalf("want PollingTimeoutError from the client default timeout, got %T: %v", err, err)
+	}
+	if !strings.Contains(pollErr.Message, "30ms") || time.Since(start) > 5*time.Second {
+		t.Errorf("want the 30ms client default to apply, got %q", pollErr.Message)
+	}
+}
+
+func TestPolling_DisableStreamOverridesClientDefault(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0),
+		WithDefaultWaitOptions(WaitOptions{PreferStream: true}))
+	if _, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{DisableStream: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/v1/verifications/ver_1" {
+		t.Errorf("want a single poll and no stream, got %v", paths)
+	}
+}
+
+func TestWithWaitDefaults(t *testing.T) {
+	defaults := &WaitOptions{Interval: time.Second, Timeout: time.Minute}
+	got := withWaitDefaults(&WaitOptions{Timeout: 5 * time.Second}, defaults)
+	if got.Interval != time.Second || got.Timeout != 5*time.Second {
+		t.Errorf("want per-call Timeout with default Interval, got %+v", got)
+	}
+	streaming := &WaitOptions{PreferStream: true}
+	if got := withWaitDefaults(&WaitOptions{DisableStream: true}, streaming); !got.DisableStream {
+		t.Errorf("want per-call DisableStream kept over a streaming default, got %+v", got)
+	}
+	if withWaitDefaults(nil, nil) != nil {
+		t.Error("nil opts without client defaults should stay nil")
+	}
+	if _, err := NewClient("pk_test_123", WithDefaultWaitOptions(WaitOptions{Timeout: -time.Second})); err == nil {
+		t.Error("want NewClient to reject invalid default wait options")
+	}
+}
+
+func TestResolveWaitOptions_ClampsDefaultInterval(t *testing.T) {
+	interval, timeout := resolveWaitOptions(&WaitOptions{Timeout: time.Second})
+	if interval != time.Second || timeout != time.Second {
+		t.Errorf("want interval clamped to 1s timeout, got interval %v timeout %v", interval, timeout)
+	}
+	if err := validateWaitOptions(&WaitOptions{Timeout: time.Second}); err != nil {
+		t.Errorf("unexpected error for default interval: %v", err)
+	}
+}
+
+func TestPolling_InitialDelay(t *testing.T) {
+	var firstPoll atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		firstPoll.CompareAndSwap(0, time.Now().UnixNano())
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	start := time.Now()
+	_, err := client.Verifications.WaitForCompletion(context.Background(), "ver_1", &WaitOptions{
+		Interval:     10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		InitialDelay: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited := time.Duration(firstPoll.Load() - start.UnixNano()); waited < 100*time.Millisecond {
+		t.Errorf("first retrieve after %v, want >= 100ms", waited)
+	}
+}
+
+func TestPolling_InitialDelayRespectsContext(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Verifications.WaitForCompletion(ctx, "ver_1", &WaitOptions{InitialDelay: 5 * time.Second})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("initial delay did not respect context cancellation")
+	}
+	if callCount.Load() != 0 {
+		t.Errorf("want no retrieve, got %d", callCount.Load())
+	}
+}
+
+func TestWaitOptions_NextPollDelay(t *testing.T) {
+	var defaults *WaitOptions
+	if d, ok := defaults.NextPollDelay(0, "pending"); !ok || d != 3*time.Second {
+		t.Errorf("nil opts: want (3s, true), got (%v, %v)", d, ok)
+	}
+
+	fixed := &WaitOptions{Interval: 500 * time.Millisecond}
+	if d, ok := fixed.NextPollDelay(7, "pending"); !ok || d != 500*time.Millisecond {
+		t.Errorf("fixed: want (500ms, true), got (%v, %v)", d, ok)
+	}
+
+	backoff := &WaitOptions{Backoff: &Backoff{Base: time.Second, Factor: 2, Max: 5 * time.Second}}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if d, ok := backoff.NextPollDelay(attempt, "pending"); !ok || d != want {
+			t.Errorf("backoff attempt %d: want (%v, true), got (%v, %v)", attempt, want, d, ok)
+		}
+	}
+
+	for _, status := range []string{"verified", "failed", "expired", "revoked", "completed", "cancelled"} {
+		if _, ok := fixed.NextPollDelay(0, status); ok {
+			t.Errorf("status %q: want ok=false for terminal status", status)
+		}
+	}
+}
+
+func TestBuildWaitOptions_Compose(t *testing.T) {
+	var called bool
+	opts := buildWaitOptions([]WaitOption{
+		WithInterval(time.Second),
+		WithWaitTimeout(time.Minute),
+		WithMaxPolls(5),
+		WithOnPoll(func(int, map[string]any) { called = true }),
+		WithInterval(2 * time.Second),
+	})
+	if opts.Interval != 2*time.Second {
+		t.Errorf("later option should win: want Interval 2s, got %s", opts.Interval)
+	}
+	if opts.Timeout != time.Minute || opts.MaxPolls != 5 {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	opts.OnPoll(0, nil)
+	if !called {
+		t.Error("OnPoll not set")
+	}
+	if got := buildWaitOptions(nil); got.Interval != 0 || got.Timeout != 0 || got.MaxPolls != 0 || got.OnPoll != nil {
+		t.Errorf("no options should give zero WaitOptions, got %+v", got)
+	}
+}
+
+func TestPolling_WaitForCompletionOpts(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := "pending"
+		if callCount.Add(1) >= 3 {
+			status = "verified"
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": status})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	var polls []string
+	result, err := client.Verifications.WaitForCompletionOpts(context.Background(), "ver_1",
+		WithInterval(5*time.Millisecond),
+		WithWaitTimeout(5*time.Second),
+		WithOnPoll(func(poll int, resource map[string]any) {
+			polls = append(polls, resource["status"].(string))
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "verified" {
+		t.Errorf("want 'verified', got %v", result["status"])
+	}
+	if strings.Join(polls, ",") != "pending,pending,verified" {
+		t.Errorf("unexpected OnPoll calls: %v", polls)
+	}
+}
+
+func TestPolling_MaxPolls(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"id": "sess_1", "status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Sessions.WaitForCompletionOpts(context.Background(), "sess_1",
+		WithInterval(time.Millisecond), WithWaitTimeout(5*time.Second), WithMaxPolls(3))
+	var timeoutErr *PollingTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("want PollingTimeoutError, got %T: %v", err, err)
+	}
+	if !strings.Contains(timeoutErr.Message, "3 polls") {
+		t.Errorf("unexpected message: %s", timeoutErr.Message)
+	}
+	if n := callCount.Load(); n != 3 {
+		t.Errorf("want 3 polls, got %d", n)
+	}
+
+	_, err = client.Sessions.WaitForCompletionOpts(context.Background(), "sess_1", WithMaxPolls(-1))
+	if err == nil || !strings.Contains(err.Error(), "MaxPolls") {
+		t.Errorf("want MaxPolls validation error, got %v", err)
+	}
+}
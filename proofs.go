@@ -2,13 +2,20 @@ package proof
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // Proofs provides access to the proofs API.
 type Proofs struct {
 	http    *httpClient
 	jwksURL string
+	jwks    *jwksCache
 }
 
 // Validate validates a proof token online (checks revocation status).
@@ -20,8 +27,86 @@ func (p *Proofs) Validate(ctx context.Context, proofToken string, identifier str
 	return p.http.post(ctx, "/api/v1/proofs/validate", body)
 }
 
+// ValidationResult is the typed outcome of Proofs.ValidateTyped.
+type ValidationResult struct {
+	Valid bool
+	// Reason explains an invalid result, e.g. "revoked" or "expired".
+	Reason string
+	// RevokedAt is set when the proof has been revoked.
+	RevokedAt *time.Time
+	// Claims are the token claims reported by the server, if any.
+	Claims ProofClaims
+	// Resource is the full response.
+	Resource map[string]any
+}
+
+// ValidateTyped validates a proof token online like Validate and decodes the
+// result. An invalid or revoked proof is reported through Valid and Reason,
+// not as an error.
+func (p *Proofs) ValidateTyped(ctx context.Context, proofToken string, identifier string) (*ValidationResult, error) {
+	resource, err := p.Validate(ctx, proofToken, identifier)
+	if err != nil {
+		return nil, err
+	}
+	result := &ValidationResult{Resource: resource}
+	result.Valid, _ = GetBool(resource, "valid")
+	result.Reason, _ = GetString(resource, "reason")
+	if revokedAt, ok := GetTime(resource, "revoked_at"); ok {
+		result.RevokedAt = &revokedAt
+	}
+	if raw, ok := resource["claims"].(map[string]any); ok {
+		data, _ := json.Marshal(raw)
+		if err := json.Unmarshal(data, &result.Claims); err != nil {
+			return nil, fmt.Errorf("failed to decode proof claims: %w", err)
+		}
+		result.Claims.Raw = raw
+	}
+	return result, nil
+}
+
+// IdentifierMismatchError is returned by Proofs.ValidateBound when the proof
+// was issued for a different identifier than the expected one, and by
+// Verifications.WaitForVerifiedProof when it was issued for a different
+// verification.
+type IdentifierMismatchError struct {
+	ProofError
+	Expected string
+	// Actual is the identifier the proof is bound to, if the server reported it.
+	Actual string
+}
+
+// ValidateBound validates a proof token online like ValidateTyped, but
+// requires the identifier (phone number, email, ...) the proof must be bound
+// to. A proof for another identifier fails with an *IdentifierMismatchError
+// rather than an invalid result; other invalid proofs are reported through
+// Valid and Reason as usual.
+func (p *Proofs) ValidateBound(ctx context.Context, proofToken string, expectedIdentifier string) (*ValidationResult, error) {
+	if expectedIdentifier == "" {
+		return nil, &ValidationError{ProofError{Message: "expected identifier must not be empty", Code: "identifier_required"}}
+	}
+	result, err := p.ValidateTyped(ctx, proofToken, expectedIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	actual := result.Claims.Identifier
+	if result.Reason == "identifier_mismatch" || (result.Valid && actual != "" && actual != expectedIdentifier) {
+		return nil, &IdentifierMismatchError{
+			ProofError: ProofError{
+				Message: fmt.Sprintf("proof is not bound to identifier %q", expectedIdentifier),
+				Code:    "identifier_mismatch",
+			},
+			Expected: expectedIdentifier,
+			Actual:   actual,
+		}
+	}
+	return result, nil
+}
+
 // Revoke revokes a proof by verification ID.
 func (p *Proofs) Revoke(ctx context.Context, id string, reason string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	var body map[string]string
 	if reason != "" {
 		body = map[string]string{"reason": reason}
@@ -29,8 +114,53 @@ func (p *Proofs) Revoke(ctx context.Context, id string, reason string) (map[stri
 	return p.http.post(ctx, "/api/v1/proofs/"+url.PathEscape(id)+"/revoke", body)
 }
 
+// RevokeBatch revokes several proofs concurrently (bounded) with the same
+// reason, e.g. during incident response. Every ID is attempted even if some
+// fail; the results are in input order and the error joins the per-ID
+// failures. Cancelling ctx stops starting new revocations.
+func (p *Proofs) RevokeBatch(ctx context.Context, ids []string, reason string) ([]BatchResult, error) {
+	return runBatch(ctx, ids, defaultBatchConcurrency, func(c context.Context, id string) (map[string]any, error) {
+		return p.Revoke(c, id, reason)
+	})
+}
+
+// RevokeReason is a reason accepted by the revocation endpoint.
+type RevokeReason string
+
+const (
+	RevokeReasonFraudulent  RevokeReason = "fraudulent"
+	RevokeReasonUserRequest RevokeReason = "user_request"
+	RevokeReasonDuplicate   RevokeReason = "duplicate"
+	RevokeReasonExpired     RevokeReason = "expired"
+)
+
+// Valid reports whether r is one of the known revocation reasons.
+func (r RevokeReason) Valid() bool {
+	switch r {
+	case RevokeReasonFraudulent, RevokeReasonUserRequest, RevokeReasonDuplicate, RevokeReasonExpired:
+		return true
+	}
+	return false
+}
+
+// RevokeWithReason revokes a proof with a typed reason, rejecting unknown
+// reasons locally with a *ValidationError before any request is made. Use
+// Revoke to send reasons this SDK version does not know about yet.
+func (p *Proofs) RevokeWithReason(ctx context.Context, id string, reason RevokeReason) (map[string]any, error) {
+	if !reason.Valid() {
+		return nil, &ValidationError{ProofError{
+			Message: fmt.Sprintf("invalid revoke reason %q", reason),
+			Code:    "invalid_revoke_reason",
+		}}
+	}
+	return p.Revoke(ctx, id, string(reason))
+}
+
 // Status gets the status of a proof by verification ID.
 func (p *Proofs) Status(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return p.http.get(ctx, "/api/v1/proofs/"+url.PathEscape(id)+"/status", nil)
 }
 
@@ -38,3 +168,62 @@ func (p *Proofs) Status(ctx context.Context, id string) (map[string]any, error)
 func (p *Proofs) ListRevoked(ctx context.Context) (map[string]any, error) {
 	return p.http.get(ctx, "/api/v1/proofs/revoked", nil)
 }
+
+// VerifyOffline verifies a proof token locally: its RS256/ES256 signature
+// against the API's published signing keys (fetched from the JWKS endpoint and
+// cached) and its expiry, which must be present. It does not check
+// revocation; use Validate for that. A token that fails any check yields an
+// *InvalidProofError.
+func (p *Proofs) VerifyOffline(ctx context.Context, proofToken string) (ProofClaims, error) {
+	if err := p.jwks.verifyJWT(ctx, proofToken); err != nil {
+		return ProofClaims{}, err
+	}
+	claims, err := p.DecodeUnverified(proofToken)
+	if err != nil {
+		return ProofClaims{}, newInvalidProofError("%v", err)
+	}
+	if claims.ExpiresAt == 0 {
+		return ProofClaims{}, newInvalidProofError("proof token has no expiry (exp claim)")
+	}
+	if !p.http.clock.Now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return ProofClaims{}, newInvalidProofError("proof token expired at %s", time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return claims, nil
+}
+
+// ProofClaims are the claims carried in a proof token's JWT payload.
+type ProofClaims struct {
+	Issuer         string `json:"iss,omitempty"`
+	Subject        string `json:"sub,omitempty"`
+	ID             string `json:"jti,omitempty"`
+	IssuedAt       int64  `json:"iat,omitempty"` // Unix seconds
+	ExpiresAt      int64  `json:"exp,omitempty"` // Unix seconds
+	VerificationID string `json:"verification_id,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Channel        string `json:"channel,omitempty"`
+	Identifier     string `json:"identifier,omitempty"`
+	// Raw holds every claim in the payload, including ones without a field above.
+	Raw map[string]any `json:"-"`
+}
+
+// DecodeUnverified decodes a proof token's claims WITHOUT verifying its
+// signature, expiry or revocation status. It is NOT a security check: use it
+// only for cheap routing decisions, and call Validate before trusting a proof.
+func (p *Proofs) DecodeUnverified(proofToken string) (ProofClaims, error) {
+	parts := strings.Split(proofToken, ".")
+	if len(parts) != 3 {
+		return ProofClaims{}, errors.New("malformed proof token: expected 3 dot-separated segments")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ProofClaims{}, fmt.Errorf("malformed proof token payload: %w", err)
+	}
+	var claims ProofClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ProofClaims{}, fmt.Errorf("malformed proof token claims: %w", err)
+	}
+	if err := json.Unmarshal(payload, &claims.Raw); err != nil {
+		return ProofClaims{}, fmt.Errorf("malformed proof token claims: %w", err)
+	}
+	return claims, nil
+}
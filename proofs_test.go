@@ -0,0 +1,219 @@
+package proof
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testToken(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"ES256","typ":"JWT"}`)) + "." +
+		enc.EncodeToString([]byte(payload)) + "." +
+		enc.EncodeToString([]byte("signature"))
+}
+
+func TestProofs_DecodeUnverified(t *testing.T) {
+	token := testToken(`{"iss":"https://api.proof.holdings","sub":"ver_123","exp":1900000000,"type":"phone","identifier":"+1234567890","custom":"x"}`)
+
+	claims, err := (&Proofs{}).DecodeUnverified(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "ver_123" {
+		t.Errorf("want sub 'ver_123', got %q", claims.Subject)
+	}
+	if claims.Identifier != "+1234567890" {
+		t.Errorf("want identifier '+1234567890', got %q", claims.Identifier)
+	}
+	if claims.ExpiresAt != 1900000000 {
+		t.Errorf("want exp 1900000000, got %d", claims.ExpiresAt)
+	}
+	if claims.Raw["custom"] != "x" {
+		t.Errorf("want raw custom claim 'x', got %v", claims.Raw["custom"])
+	}
+}
+
+func TestProofs_DecodeUnverified_Malformed(t *testing.T) {
+	for _, token := range []string{
+		"",
+		"not-a-jwt",
+		"a.b",
+		"a.!!!.c",
+		testToken(`not json`),
+	} {
+		if _, err := (&Proofs{}).DecodeUnverified(token); err == nil {
+			t.Errorf("expected error for %q", token)
+		}
+	}
+}
+
+func TestProofs_ValidateTyped(t *testing.T) {
+	responses := map[string]map[string]any{
+		"tok_valid": {
+			"valid":  true,
+			"claims": map[string]any{"sub": "ver_1", "type": "phone", "exp": 1900000000},
+		},
+		"tok_revoked": {
+			"valid":      false,
+			"reason":     "revoked",
+			"revoked_at": "2026-10-15T12:00:00Z",
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/proofs/validate" {
+			t.Errorf("want POST /api/v1/proofs/validate, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(responses[body["proof_token"]])
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	valid, err := client.Proofs.ValidateTyped(context.Background(), "tok_valid", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !valid.Valid || valid.RevokedAt != nil || valid.Claims.Subject != "ver_1" || valid.Claims.ExpiresAt != 1900000000 {
+		t.Errorf("unexpected valid result: %+v", valid)
+	}
+
+	revoked, err := client.Proofs.ValidateTyped(context.Background(), "tok_revoked", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked.Valid || revoked.Reason != "revoked" {
+		t.Errorf("want invalid with reason 'revoked', got %+v", revoked)
+	}
+	if want := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC); revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(want) {
+		t.Errorf("want RevokedAt %v, got %v", want, revoked.RevokedAt)
+	}
+}
+
+func TestProofs_RevokeWithReason(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/proofs/ver_1/revoke" {
+			t.Errorf("want POST /api/v1/proofs/ver_1/revoke, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["reason"] != "fraudulent" {
+			t.Errorf("want reason 'fraudulent', got %q", body["reason"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"revoked": true})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	if _, err := client.Proofs.RevokeWithReason(context.Background(), "ver_1", RevokeReasonFraudulent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProofs_RevokeWithReasonRejectsUnknown(t *testing.T) {
+	client, _ := NewClient("pk_test_123", WithBaseURL("http://127.0.0.1:1"))
+	_, err := client.Proofs.RevokeWithReason(context.Background(), "ver_1", RevokeReason("because"))
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("want ValidationError, got %T: %v", err, err)
+	}
+	if valErr.Code != "invalid_revoke_reason" {
+		t.Errorf("want code 'invalid_revoke_reason', got %q", valErr.Code)
+	}
+}
+
+func TestProofs_RevokeBatch(t *testing.T) {
+	var revoked sync.Map
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/"), "/revoke")
+		if id == "ver_bad" {
+			w.WriteHeader(404)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "not_found", "message": "Not found"}})
+			return
+		}
+		revoked.Store(id, true)
+		json.NewEncoder(w).Encode(map[string]any{"id": id, "revoked": true})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	ids := []string{"ver_1", "ver_bad", "ver_2", "ver_3"}
+	results, err := client.Proofs.RevokeBatch(context.Background(), ids, "fraudulent")
+
+	var nfErr *NotFoundError
+	if !errors.As(err, &nfErr) {
+		t.Fatalf("want aggregated error containing NotFoundError, got %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("want %d results, got %d", len(ids), len(results))
+	}
+	for i, r := range results {
+		if r.ID != ids[i] {
+			t.Errorf("result %d: want id %q, got %q", i, ids[i], r.ID)
+		}
+		if (r.Err != nil) != (r.ID == "ver_bad") {
+			t.Errorf("result %q: unexpected error state %v", r.ID, r.Err)
+		}
+	}
+	for _, id := range []string{"ver_1", "ver_2", "ver_3"} {
+		if _, ok := revoked.Load(id); !ok {
+			t.Errorf("%s was not revoked", id)
+		}
+	}
+}
+
+func TestProofs_ValidateBound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		claims := map[string]any{"sub": "ver_1", "type": "phone", "identifier": "+15551234567"}
+		switch {
+		case body["identifier"] == "":
+			t.Error("want identifier sent to the server")
+		case body["proof_token"] == "tok_server_mismatch":
+			json.NewEncoder(w).Encode(map[string]any{"valid": false, "reason": "identifier_mismatch"})
+		case body["proof_token"] == "tok_revoked":
+			json.NewEncoder(w).Encode(map[string]any{"valid": false, "reason": "revoked", "claims": claims})
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"valid": true, "claims": claims})
+		}
+	}))
+	defer srv.Close()
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	result, err := client.Proofs.ValidateBound(ctx, "tok_1", "+15551234567")
+	if err != nil || !result.Valid {
+		t.Fatalf("want valid match, got %+v, %v", result, err)
+	}
+
+	for token, wantActual := range map[string]string{"tok_server_mismatch": "", "tok_1": "+15551234567"} {
+		_, err = client.Proofs.ValidateBound(ctx, token, "+15559999999")
+		var mismatch *IdentifierMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("%s: want IdentifierMismatchError, got %T: %v", token, err, err)
+		}
+		if mismatch.Expected != "+15559999999" || mismatch.Actual != wantActual {
+			t.Errorf("%s: unexpected mismatch details %+v", token, mismatch)
+		}
+	}
+
+	result, err = client.Proofs.ValidateBound(ctx, "tok_revoked", "+15551234567")
+	if err != nil || result.Valid || result.Reason != "revoked" {
+		t.Errorf("want plain invalid result for a revoked proof, got %+v, %v", result, err)
+	}
+
+	_, err = client.Proofs.ValidateBound(ctx, "tok_1", "")
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Code != "identifier_required" {
+		t.Errorf("want identifier_required ValidationError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,82 @@
+package proof
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequestOption configures a single RawRequest call. It is the explicit
+// alternative to the context helpers (WithHeader, WithRetries).
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	headers http.Header
+	retries *int
+}
+
+// WithIdempotencyKeyOpt sends an Idempotency-Key header so the server can
+// deduplicate retried writes.
+func WithIdempotencyKeyOpt(key string) RequestOption {
+	return WithHeaderOpt("Idempotency-Key", key)
+}
+
+// WithHeaderOpt adds a header to the call, like WithHeader, with the same
+// restrictions on SDK-owned headers and control characters.
+func WithHeaderOpt(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		o.headers.Add(key, value)
+	}
+}
+
+// WithRetriesOpt overrides the maximum number of retries for the call, like
+// WithRetries.
+func WithRetriesOpt(n int) RequestOption {
+	return func(o *requestOptions) { o.retries = &n }
+}
+
+// apply folds the options into ctx so they travel the same path as the
+// context helpers.
+func (o *requestOptions) apply(ctx context.Context) context.Context {
+	for key, values := range o.headers {
+		for _, value := range values {
+			ctx = WithHeader(ctx, key, value)
+		}
+	}
+	if o.retries != nil {
+		ctx = WithRetries(ctx, *o.retries)
+	}
+	return ctx
+}
+
+// RawRequest calls an endpoint this SDK has no method for yet. path is
+// relative to the base URL (e.g. "/api/v1/new-endpoint?limit=10") and body,
+// if non-nil, is sent as JSON. The call gets the client's authentication,
+// retries and error mapping; the response is decoded like any other.
+func (c *Client) RawRequest(ctx context.Context, method, path string, body any, opts ...RequestOption) (map[string]any, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("raw request path must start with \"/\", got %q", path)
+	}
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return c.http.request(o.apply(ctx), method, path, body, nil)
+}
+
+// AuthorizeRequest sets the headers the client sends on its own requests
+// (Authorization, User-Agent, Content-Type, Accept, and any WithSource or
+// WithHeader values in req's context) on a request built by hand, e.g. for
+// an endpoint RawRequest does not suit. It does not send the request. Like any
+// call, it fails without touching req if those context values are invalid.
+func (c *Client) AuthorizeRequest(req *http.Request) error {
+	if err := validateContextHeaders(req.Context()); err != nil {
+		return err
+	}
+	c.http.setHeaders(req)
+	return nil
+}
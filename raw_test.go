@@ -0,0 +1,139 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RawRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/new-endpoint" || r.URL.Query().Get("dry_run") != "true" {
+			t.Errorf("want POST /api/v1/new-endpoint?dry_run=true, got %s %s", r.Method, r.URL)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Acme" {
+			t.Errorf("want name 'Acme', got %v", body["name"])
+		}
+		if r.Header.Get("Idempotency-Key") != "idem_1" {
+			t.Errorf("want Idempotency-Key 'idem_1', got %q", r.Header.Get("Idempotency-Key"))
+		}
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("want X-Tenant 'acme', got %q", r.Header.Get("X-Tenant"))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "new_1"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	result, err := client.RawRequest(context.Background(), http.MethodPost, "/api/v1/new-endpoint?dry_run=true",
+		map[string]any{"name": "Acme"},
+		WithIdempotencyKeyOpt("idem_1"),
+		WithHeaderOpt("X-Tenant", "acme"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["id"] != "new_1" {
+		t.Errorf("want id 'new_1', got %v", result["id"])
+	}
+}
+
+func TestClient_RawRequestPathQueryWithRawQuery(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := WithRawQuery(context.Background(), url.Values{"beta": {"1"}, "limit": {"50"}})
+	if _, err := client.RawRequest(ctx, http.MethodGet, "/api/v1/new-endpoint?limit=10", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("beta") != "1" {
+		t.Errorf("want beta=1 from WithRawQuery, got %v", got)
+	}
+	if l := got["limit"]; len(l) != 1 || l[0] != "10" {
+		t.Errorf("want limit=10 from the path kept, got %v", l)
+	}
+}
+
+func TestClient_RawRequestRetriesOpt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(503)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	client.http.retryBackoff = Backoff{Base: time.Millisecond}
+	if _, err := client.RawRequest(context.Background(), http.MethodGet, "/api/v1/test", nil, WithRetriesOpt(2)); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("want 3 attempts with WithRetriesOpt(2), got %d", calls.Load())
+	}
+}
+
+func TestClient_RawRequestRelativePath(t *testing.T) {
+	client, _ := NewClient("pk_test_123")
+	if _, err := client.RawRequest(context.Background(), http.MethodGet, "api/v1/test", nil); err == nil {
+		t.Fatal("expected error for a path without a leading slash")
+	}
+}
+
+func TestClient_AuthorizeRequest(t *testing.T) {
+	var internal http.Header
+	client, _ := NewClient("pk_test_123", WithBaseURL("https://api.proof.test"), WithMaxRetries(0))
+	client.http.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		internal = r.Header.Clone()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}, nil
+	})
+	ctx := WithHeader(WithSource(context.Background(), "gateway"), "X-Tenant", "acme")
+	if _, err := client.Verifications.Retrieve(ctx, "ver_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.proof.test/api/v1/new-endpoint", nil)
+	if err := client.AuthorizeRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(req.Header, internal) {
+		t.Errorf("AuthorizeRequest headers = %v, want %v", req.Header, internal)
+	}
+	if req.Header.Get("Authorization") != "Bearer pk_test_123" {
+		t.Errorf("unexpected Authorization %q", req.Header.Get("Authorization"))
+	}
+}
+
+func TestClient_AuthorizeRequestValidatesContext(t *testing.T) {
+	client, _ := NewClient("pk_test_123")
+	for name, ctx := range map[string]context.Context{
+		"bad source":           WithSource(context.Background(), "bad label!"),
+		"authorization header": WithHeader(context.Background(), "Authorization", "Bearer pk_live_other"),
+	} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.proof.test/api/v1/new-endpoint", nil)
+		if err := client.AuthorizeRequest(req); err == nil {
+			t.Errorf("%s: want a validation error", name)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Errorf("%s: want req left untouched, got Authorization %q", name, req.Header.Get("Authorization"))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package proof
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ErrorRedactor transforms error Details before an error is returned, e.g. to
+// strip personal data that the API echoed back.
+type ErrorRedactor func(details any) any
+
+// WithErrorRedactor replaces the default Details redactor. Pass nil to keep
+// Details exactly as the API returned them.
+func WithErrorRedactor(fn ErrorRedactor) ClientOption {
+	return func(c *clientConfig) { c.errorRedactor = fn }
+}
+
+const redactedValue = "[REDACTED]"
+
+var sensitiveKeyParts = []string{"phone", "email", "identifier", "token", "secret", "password"}
+
+// DefaultErrorRedactor masks values whose keys look sensitive (phone, email,
+// identifier, token, secret, password, code, *_code) anywhere in nested maps
+// and slices. The input is not modified.
+func DefaultErrorRedactor(details any) any {
+	switch v := details.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			if isSensitiveKey(key) && val != nil {
+				out[key] = redactedValue
+				continue
+			}
+			out[key] = DefaultErrorRedactor(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = DefaultErrorRedactor(val)
+		}
+		return out
+	}
+	return details
+}
+
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	if k == "code" || strings.HasSuffix(k, "_code") {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiKeyPattern matches proof.holdings API keys.
+var apiKeyPattern = regexp.MustCompile(`pk_(live|test)_[A-Za-z0-9_\-]+`)
+
+// scrubAPIKeys masks anything that looks like an API key in s, keeping the
+// pk_live_/pk_test_ prefix so the mode stays visible.
+func scrubAPIKeys(s string) string {
+	return apiKeyPattern.ReplaceAllString(s, "pk_${1}_"+redactedValue)
+}
+
+// newNetworkError builds a NetworkError from a transport error. Transports and
+// proxies sometimes echo URLs or headers into their errors, so API keys are
+// scrubbed from the message.
+func newNetworkError(err error) *NetworkError {
+	return &NetworkError{ProofError{Message: scrubAPIKeys(err.Error()), Code: "network_error"}}
+}
@@ -0,0 +1,99 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func validationErrorWithDetails(t *testing.T, opts ...ClientOption) *ValidationError {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"code":    "invalid_phone",
+				"message": "Invalid phone number",
+				"details": map[string]any{
+					"field": "identifier",
+					"phone": "+1234567890",
+					"errors": []any{
+						map[string]any{"email": "a@example.com", "reason": "blocked"},
+					},
+				},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _ := NewClient("pk_test_123", append([]ClientOption{WithBaseURL(srv.URL)}, opts...)...)
+	_, err := client.Verifications.Create(context.Background(), map[string]any{"type": "phone"})
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("want ValidationError, got %T: %v", err, err)
+	}
+	return valErr
+}
+
+func TestErrorRedactor_DefaultMasksSensitiveDetails(t *testing.T) {
+	details := validationErrorWithDetails(t).Details.(map[string]any)
+	if details["phone"] != "[REDACTED]" {
+		t.Errorf("want phone redacted, got %v", details["phone"])
+	}
+	if details["field"] != "identifier" {
+		t.Errorf("want non-sensitive field kept, got %v", details["field"])
+	}
+	nested := details["errors"].([]any)[0].(map[string]any)
+	if nested["email"] != "[REDACTED]" || nested["reason"] != "blocked" {
+		t.Errorf("want nested email redacted and reason kept, got %v", nested)
+	}
+}
+
+func TestErrorRedactor_Custom(t *testing.T) {
+	valErr := validationErrorWithDetails(t, WithErrorRedactor(func(any) any { return "hidden" }))
+	if valErr.Details != "hidden" {
+		t.Errorf("want custom redactor applied, got %v", valErr.Details)
+	}
+}
+
+func TestErrorRedactor_Disabled(t *testing.T) {
+	details := validationErrorWithDetails(t, WithErrorRedactor(nil)).Details.(map[string]any)
+	if details["phone"] != "+1234567890" {
+		t.Errorf("want raw phone with redaction disabled, got %v", details["phone"])
+	}
+}
+
+func TestDefaultErrorRedactor_DoesNotMutateInput(t *testing.T) {
+	in := map[string]any{"token": "secret", "verification_code": "123456", "count": 3.0}
+	out := DefaultErrorRedactor(in).(map[string]any)
+	if in["token"] != "secret" {
+		t.Error("input map was modified")
+	}
+	if out["token"] != "[REDACTED]" || out["verification_code"] != "[REDACTED]" || out["count"] != 3.0 {
+		t.Errorf("unexpected redaction result: %v", out)
+	}
+}
+
+func TestNetworkErrorScrubsAPIKeys(t *testing.T) {
+	client := newHTTPClient("pk_live_abc123", "https://api.proof.holdings", 5e9, 0)
+	client.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("proxy rejected https://gw.example/?key=pk_live_abc123 (fallback pk_test_XyZ-9_8)")
+	})
+
+	_, err := client.get(context.Background(), "/test", nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("want NetworkError, got %T: %v", err, err)
+	}
+	if strings.Contains(err.Error(), "abc123") || strings.Contains(err.Error(), "XyZ") {
+		t.Errorf("API keys should be scrubbed, got %q", err)
+	}
+	if !strings.Contains(err.Error(), "pk_live_[REDACTED]") || !strings.Contains(err.Error(), "pk_test_[REDACTED]") {
+		t.Errorf("want masked keys with their prefixes, got %q", err)
+	}
+}
@@ -0,0 +1,11 @@
+package proof
+
+import "crypto/subtle"
+
+// SecureCompare reports whether a and b are equal in time that does not depend
+// on their contents, for checking shared secrets such as webhook tokens in a
+// custom receiver. Strings of different length compare unequal immediately, so
+// only the length can leak.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,21 @@
+package proof
+
+import "testing"
+
+func TestSecureCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"whsec_abc123", "whsec_abc123", true},
+		{"", "", true},
+		{"whsec_abc123", "whsec_abc124", false},
+		{"whsec_abc123", "whsec_abc12", false},
+		{"", "whsec_abc123", false},
+	}
+	for _, tt := range tests {
+		if got := SecureCompare(tt.a, tt.b); got != tt.want {
+			t.Errorf("SecureCompare(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -17,6 +17,9 @@ func (s *Sessions) Create(ctx context.Context, params map[string]any) (map[strin
 
 // Retrieve gets session status by ID.
 func (s *Sessions) Retrieve(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return s.http.get(ctx, "/api/v1/sessions/"+url.PathEscape(id), nil)
 }
 
@@ -24,13 +27,35 @@ func (s *Sessions) Retrieve(ctx context.Context, id string) (map[string]any, err
 func (s *Sessions) WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error) {
 	return pollUntilComplete(
 		ctx,
+		s.http.clock,
 		func(c context.Context) (map[string]any, error) { return s.Retrieve(c, id) },
 		isTerminalSessionStatus,
 		"Session "+id,
-		opts,
+		withWaitDefaults(opts, s.http.waitDefaults),
 	)
 }
 
+// WaitForCompletionOpts is WaitForCompletion configured with WaitOption
+// functions instead of a *WaitOptions.
+func (s *Sessions) WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error) {
+	return s.WaitForCompletion(ctx, id, buildWaitOptions(opts))
+}
+
+// SessionStatus is the "status" of a session.
+type SessionStatus string
+
+const (
+	SessionStatusPending  SessionStatus = "pending"
+	SessionStatusVerified SessionStatus = "verified"
+	SessionStatusFailed   SessionStatus = "failed"
+	SessionStatusExpired  SessionStatus = "expired"
+)
+
+// IsTerminal reports whether the session can no longer change status.
+func (s SessionStatus) IsTerminal() bool {
+	return s == SessionStatusVerified || s == SessionStatusFailed || s == SessionStatusExpired
+}
+
 func isTerminalSessionStatus(s string) bool {
-	return s == "verified" || s == "failed" || s == "expired"
+	return SessionStatus(s).IsTerminal()
 }
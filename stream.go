@@ -0,0 +1,192 @@
+package proof
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StatusEvent is a status change emitted by a status stream.
+type StatusEvent struct {
+	Status   string         // the resource's status after the change
+	Resource map[string]any // the event payload (or polled resource)
+	// Err is set on the final event when the stream failed before the resource
+	// reached a terminal status. Status and Resource are empty in that case.
+	Err error
+}
+
+// openEventStream issues a GET that expects a text/event-stream response. It
+// returns (nil, nil) when the server answers with anything else, so callers
+// can fall back to polling. The stream uses the configured http.Client
+// without its timeout; use ctx to bound it.
+func (h *httpClient) openEventStream(ctx context.Context, path string) (*http.Response, error) {
+	if err := validateContextHeaders(ctx); err != nil {
+		return nil, err
+	}
+	baseURL, err := h.baseURLFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	h.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	streamClient := *h.client
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, newNetworkError(err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return nil, nil
+	}
+	return resp, nil
+}
+
+// readEvents parses Server-Sent Events from r and calls fn with each event's
+// data. It stops when fn returns false or r is exhausted; an incomplete
+// trailing event is discarded, as the SSE spec requires.
+func readEvents(r io.Reader, fn func(data []byte) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var data []byte
+	hasData := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if hasData && !fn(data) {
+				return nil
+			}
+			data, hasData = nil, false
+		case strings.HasPrefix(line, "data:"):
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")...)
+			hasData = true
+		}
+	}
+	return scanner.Err()
+}
+
+// streamStatus emits status changes for a resource, preferring the SSE
+// endpoint at streamPath and falling back to polling retrieve when the server
+// does not offer a stream. The channel is closed once a terminal status is
+// emitted, the stream fails, or ctx is done.
+func streamStatus(
+	ctx context.Context,
+	h *httpClient,
+	streamPath string,
+	retrieve func(context.Context) (map[string]any, error),
+	isTerminal func(string) bool,
+) (<-chan StatusEvent, error) {
+	resp, err := h.openEventStream(ctx, streamPath)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan StatusEvent, 1)
+	go func() {
+		defer close(events)
+		if resp == nil {
+			interval, _ := resolveWaitOptions(nil)
+			pollStatusEvents(ctx, retrieve, isTerminal, interval, events)
+			return
+		}
+		defer resp.Body.Close()
+
+		terminal, err := readStatusStream(resp.Body, isTerminal, func(ev StatusEvent) bool {
+			return sendEvent(ctx, events, ev)
+		})
+		if terminal || ctx.Err() != nil {
+			return
+		}
+		msg := "status stream closed before reaching a terminal status"
+		if err != nil {
+			msg += ": " + err.Error()
+		}
+		sendEvent(ctx, events, StatusEvent{Err: &NetworkError{ProofError{Message: msg, Code: "stream_closed"}}})
+	}()
+	return events, nil
+}
+
+// readStatusStream reads status events from an SSE body, calling emit for each
+// event that carries a status, until a terminal status is seen, emit returns
+// false, or the body ends. It reports whether a terminal status was emitted.
+func readStatusStream(body io.Reader, isTerminal func(string) bool, emit func(StatusEvent) bool) (bool, error) {
+	terminal := false
+	err := readEvents(body, func(data []byte) bool {
+		var payload map[string]any
+		if json.Unmarshal(data, &payload) != nil {
+			return true // keep-alives and non-JSON events carry no status
+		}
+		status, _ := payload["status"].(string)
+		if status == "" {
+			return true
+		}
+		if !emit(StatusEvent{Status: status, Resource: payload}) {
+			return false
+		}
+		terminal = isTerminal(status)
+		return !terminal
+	})
+	return terminal, err
+}
+
+// pollStatusEvents polls retrieve every interval and emits an event whenever
+// the status changes, until a terminal status, an error, or ctx is done.
+func pollStatusEvents(
+	ctx context.Context,
+	retrieve func(context.Context) (map[string]any, error),
+	isTerminal func(string) bool,
+	interval time.Duration,
+	events chan<- StatusEvent,
+) {
+	last := ""
+	for {
+		resource, err := retrieve(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				sendEvent(ctx, events, StatusEvent{Err: err})
+			}
+			return
+		}
+		status, _ := resource["status"].(string)
+		if status != last {
+			if !sendEvent(ctx, events, StatusEvent{Status: status, Resource: resource}) {
+				return
+			}
+			last = status
+		}
+		if isTerminal(status) {
+			return
+		}
+		if sleepCtx(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+// sendEvent delivers ev unless ctx is done first. It reports whether ev was sent.
+func sendEvent(ctx context.Context, events chan<- StatusEvent, ev StatusEvent) bool {
+	select {
+	case events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,146 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func collectEvents(t *testing.T, events <-chan StatusEvent) []StatusEvent {
+	t.Helper()
+	var got []StatusEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, ev)
+		case <-timeout:
+			t.Fatal("timed out waiting for stream to close")
+		}
+	}
+}
+
+func TestVerifications_StreamSSE(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/verifications/ver_1/stream" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("want Accept text/event-stream, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: status\ndata: {\"id\":\"ver_1\",\"status\":\"pending\"}\n\n")
+		flusher.Flush()
+		fmt.Fprint(w, "event: status\ndata: {\"id\":\"ver_1\",\n")
+		fmt.Fprint(w, "data: \"status\":\"verified\"}\n\n")
+		flusher.Flush()
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	events, err := client.Verifications.Stream(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := collectEvents(t, events)
+	if len(got) != 2 {
+		t.Fatalf("want 2 events, got %d: %+v", len(got), got)
+	}
+	if got[0].Status != "pending" || got[1].Status != "verified" {
+		t.Errorf("want pending then verified, got %q then %q", got[0].Status, got[1].Status)
+	}
+	if got[1].Resource["id"] != "ver_1" {
+		t.Errorf("want resource id 'ver_1', got %v", got[1].Resource["id"])
+	}
+}
+
+func TestVerifications_StreamUsesConfiguredClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value != "abc" {
+			t.Errorf("want the jar's session cookie, got %v", r.Cookies())
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, "data: {\"status\":\"verified\"}\n\n")
+	}))
+	defer srv.Close()
+
+	jar, _ := cookiejar.New(nil)
+	u, _ := url.Parse(srv.URL)
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "abc"}})
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithHTTPClient(&http.Client{Jar: jar, Timeout: 10 * time.Millisecond}))
+	events, err := client.Verifications.Stream(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := collectEvents(t, events)
+	if len(got) != 1 || got[0].Status != "verified" {
+		t.Errorf("want the stream to outlive the client timeout, got %+v", got)
+	}
+}
+
+func TestVerifications_StreamValidatesContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := WithHeader(context.Background(), "X-Tenant", "acme\r\nX-Injected: 1")
+	if _, err := client.Verifications.Stream(ctx, "ver_1"); err == nil {
+		t.Fatal("expected invalid context headers to be rejected")
+	}
+}
+
+func TestVerifications_StreamClosedEarly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"status\":\"pending\"}\n\n")
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	events, err := client.Verifications.Stream(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := collectEvents(t, events)
+	if len(got) != 2 || got[0].Status != "pending" || got[1].Err == nil {
+		t.Fatalf("want pending then an error event, got %+v", got)
+	}
+}
+
+func TestVerifications_StreamFallsBackToPolling(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stream") {
+			w.WriteHeader(404)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "not_found"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "verified"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	events, err := client.Verifications.Stream(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := collectEvents(t, events)
+	if len(got) != 1 || got[0].Status != "verified" {
+		t.Fatalf("want a single verified event from polling, got %+v", got)
+	}
+}
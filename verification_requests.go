@@ -2,7 +2,10 @@ package proof
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"time"
 )
 
 // VerificationRequests provides access to the verification requests API.
@@ -10,6 +13,71 @@ type VerificationRequests struct {
 	http *httpClient
 }
 
+// AssetType is the kind of asset a verification request asks the user to
+// verify.
+type AssetType string
+
+// Asset types accepted in a verification request.
+const (
+	AssetPhone  AssetType = "phone"
+	AssetEmail  AssetType = "email"
+	AssetDomain AssetType = "domain"
+)
+
+// AssetRequirement is one entry of a verification request's assets.
+type AssetRequirement struct {
+	Type     AssetType
+	Required bool
+	// Identifier optionally pre-fills the asset, e.g. an email address.
+	Identifier string
+}
+
+// VerificationRequestParams is a typed alternative to building the
+// VerificationRequests.Create map by hand; Build validates it and returns
+// the map. Zero optional fields are omitted.
+type VerificationRequestParams struct {
+	Assets      []AssetRequirement
+	ReferenceID string
+	CallbackURL string
+	// ExpiresIn is sent in whole seconds.
+	ExpiresIn time.Duration
+}
+
+// Build validates the params and returns them in the form Create expects.
+// At least one asset is required and every asset type must be known.
+func (p *VerificationRequestParams) Build() (map[string]any, error) {
+	if len(p.Assets) == 0 {
+		return nil, &ValidationError{ProofError{Message: "at least one asset is required", Code: "invalid_params"}}
+	}
+	assets := make([]map[string]any, 0, len(p.Assets))
+	for i, a := range p.Assets {
+		switch a.Type {
+		case AssetPhone, AssetEmail, AssetDomain:
+		default:
+			return nil, &ValidationError{ProofError{
+				Message: fmt.Sprintf("assets[%d]: unknown asset type %q", i, a.Type),
+				Code:    "invalid_params",
+			}}
+		}
+		asset := map[string]any{"type": string(a.Type), "required": a.Required}
+		if a.Identifier != "" {
+			asset["identifier"] = a.Identifier
+		}
+		assets = append(assets, asset)
+	}
+	params := map[string]any{"assets": assets}
+	if p.ReferenceID != "" {
+		params["reference_id"] = p.ReferenceID
+	}
+	if p.CallbackURL != "" {
+		params["callback_url"] = p.CallbackURL
+	}
+	if p.ExpiresIn > 0 {
+		params["expires_in"] = int(p.ExpiresIn / time.Second)
+	}
+	return params, nil
+}
+
 // Create creates a multi-asset verification request.
 func (vr *VerificationRequests) Create(ctx context.Context, params map[string]any) (map[string]any, error) {
 	return vr.http.post(ctx, "/api/v1/verification-requests", params)
@@ -17,27 +85,86 @@ func (vr *VerificationRequests) Create(ctx context.Context, params map[string]an
 
 // Retrieve gets a verification request by ID.
 func (vr *VerificationRequests) Retrieve(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return vr.http.get(ctx, "/api/v1/verification-requests/"+url.PathEscape(id), nil)
 }
 
 // List lists verification requests with optional filters.
 func (vr *VerificationRequests) List(ctx context.Context, params map[string]string) (map[string]any, error) {
-	q := url.Values{}
-	for k, val := range params {
-		if val != "" {
-			q.Set(k, val)
-		}
+	return vr.http.get(ctx, "/api/v1/verification-requests", queryFromMap(params))
+}
+
+// verificationRequestSortColumns are the columns ListWithOptions can sort by.
+var verificationRequestSortColumns = []string{"created_at", "updated_at", "status"}
+
+// ListWithOptions lists verification requests with typed filters, sortable
+// by created_at, updated_at or status.
+func (vr *VerificationRequests) ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error) {
+	q, err := opts.query(verificationRequestSortColumns...)
+	if err != nil {
+		return nil, err
 	}
 	return vr.http.get(ctx, "/api/v1/verification-requests", q)
 }
 
 // GetByReference gets a verification request by its reference ID.
 func (vr *VerificationRequests) GetByReference(ctx context.Context, referenceID string) (map[string]any, error) {
+	if err := requireSegment("reference ID", referenceID); err != nil {
+		return nil, err
+	}
 	return vr.http.get(ctx, "/api/v1/verification-requests/by-reference/"+url.PathEscape(referenceID), nil)
 }
 
+// GetByReferenceOrNil is GetByReference for find-or-create flows: a missing
+// reference yields (nil, false, nil) instead of a *NotFoundError. Other errors
+// are returned as-is.
+func (vr *VerificationRequests) GetByReferenceOrNil(ctx context.Context, referenceID string) (map[string]any, bool, error) {
+	resource, err := vr.GetByReference(ctx, referenceID)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return resource, true, nil
+}
+
+// EnsureByReference returns the verification request for referenceID, creating
+// it from params (with reference_id set) if none exists yet. created reports
+// which happened. The create carries an Idempotency-Key derived from
+// referenceID, and a 409 from a concurrent create is resolved by fetching the
+// winner, so racing callers end up with the same request.
+func (vr *VerificationRequests) EnsureByReference(ctx context.Context, referenceID string, params map[string]any) (resource map[string]any, created bool, err error) {
+	resource, found, err := vr.GetByReferenceOrNil(ctx, referenceID)
+	if err != nil || found {
+		return resource, false, err
+	}
+
+	body := make(map[string]any, len(params)+1)
+	for k, v := range params {
+		body[k] = v
+	}
+	body["reference_id"] = referenceID
+	resource, err = vr.Create(WithHeader(ctx, "Idempotency-Key", "ensure-by-reference:"+referenceID), body)
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		resource, err = vr.GetByReference(ctx, referenceID)
+		return resource, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, true, nil
+}
+
 // Cancel cancels a pending verification request.
 func (vr *VerificationRequests) Cancel(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return vr.http.del(ctx, "/api/v1/verification-requests/"+url.PathEscape(id))
 }
 
@@ -45,13 +172,49 @@ func (vr *VerificationRequests) Cancel(ctx context.Context, id string) (map[stri
 func (vr *VerificationRequests) WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error) {
 	return pollUntilComplete(
 		ctx,
+		vr.http.clock,
 		func(c context.Context) (map[string]any, error) { return vr.Retrieve(c, id) },
 		isTerminalRequestStatus,
 		"Verification request "+id,
-		opts,
+		withWaitDefaults(opts, vr.http.waitDefaults),
 	)
 }
 
+// WaitForCompletionOpts is WaitForCompletion configured with WaitOption
+// functions instead of a *WaitOptions.
+func (vr *VerificationRequests) WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error) {
+	return vr.WaitForCompletion(ctx, id, buildWaitOptions(opts))
+}
+
+// CreateAndWait creates a multi-asset verification request and polls it until
+// it reaches a terminal state.
+func (vr *VerificationRequests) CreateAndWait(ctx context.Context, params map[string]any, opts *WaitOptions) (map[string]any, error) {
+	created, err := vr.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := extractID(created)
+	if !ok {
+		return nil, errors.New("verification request create response did not include an id")
+	}
+	return vr.WaitForCompletion(ctx, id, opts)
+}
+
+// VerificationRequestStatus is the "status" of a verification request.
+type VerificationRequestStatus string
+
+const (
+	VerificationRequestStatusPending   VerificationRequestStatus = "pending"
+	VerificationRequestStatusCompleted VerificationRequestStatus = "completed"
+	VerificationRequestStatusExpired   VerificationRequestStatus = "expired"
+	VerificationRequestStatusCancelled VerificationRequestStatus = "cancelled"
+)
+
+// IsTerminal reports whether the request can no longer change status.
+func (s VerificationRequestStatus) IsTerminal() bool {
+	return s == VerificationRequestStatusCompleted || s == VerificationRequestStatusExpired || s == VerificationRequestStatusCancelled
+}
+
 func isTerminalRequestStatus(s string) bool {
-	return s == "completed" || s == "expired" || s == "cancelled"
+	return VerificationRequestStatus(s).IsTerminal()
 }
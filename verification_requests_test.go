@@ -0,0 +1,198 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerificationRequests_CreateAndWait(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/verification-requests":
+			json.NewEncoder(w).Encode(map[string]any{"id": "vr_1", "status": "pending"})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/verification-requests/vr_1":
+			status := "pending"
+			if polls.Add(1) >= 2 {
+				status = "completed"
+			}
+			json.NewEncoder(w).Encode(map[string]any{"id": "vr_1", "status": status})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	result, err := client.VerificationRequests.CreateAndWait(context.Background(), map[string]any{
+		"assets": []map[string]any{{"type": "phone", "required": true}},
+	}, &WaitOptions{Interval: 10 * time.Millisecond, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "completed" {
+		t.Errorf("want status 'completed', got %v", result["status"])
+	}
+	if polls.Load() != 2 {
+		t.Errorf("want 2 polls, got %d", polls.Load())
+	}
+}
+
+func TestVerificationRequests_CreateAndWaitMissingID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"status": "pending"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	if _, err := client.VerificationRequests.CreateAndWait(context.Background(), nil, nil); err == nil {
+		t.Fatal("expected error when create response has no id")
+	}
+}
+
+func TestVerificationRequests_GetByReferenceOrNil(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/verification-requests/by-reference/order_1":
+			json.NewEncoder(w).Encode(map[string]any{"id": "vr_1", "reference_id": "order_1"})
+		case "/api/v1/verification-requests/by-reference/order_missing":
+			w.WriteHeader(404)
+		default:
+			w.WriteHeader(403)
+		}
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	ctx := context.Background()
+
+	resource, found, err := client.VerificationRequests.GetByReferenceOrNil(ctx, "order_1")
+	if err != nil || !found || resource["id"] != "vr_1" {
+		t.Errorf("want found vr_1, got %v, %t, %v", resource, found, err)
+	}
+
+	resource, found, err = client.VerificationRequests.GetByReferenceOrNil(ctx, "order_missing")
+	if err != nil || found || resource != nil {
+		t.Errorf("want (nil, false, nil) for missing reference, got %v, %t, %v", resource, found, err)
+	}
+
+	_, found, err = client.VerificationRequests.GetByReferenceOrNil(ctx, "order_forbidden")
+	var forbidden *ForbiddenError
+	if !errors.As(err, &forbidden) || found {
+		t.Errorf("want ForbiddenError propagated, got %t, %v", found, err)
+	}
+}
+
+func TestVerificationRequests_EnsureByReference(t *testing.T) {
+	var creates atomic.Int32
+	existing := map[string]bool{"order_1": true}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			ref := r.URL.Path[len("/api/v1/verification-requests/by-reference/"):]
+			if !existing[ref] {
+				w.WriteHeader(404)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"id": "vr_existing", "reference_id": ref})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/verification-requests":
+			creates.Add(1)
+			if r.Header.Get("Idempotency-Key") == "" {
+				t.Error("create should carry an Idempotency-Key")
+			}
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["reference_id"] != "order_2" || body["channel"] != "email" {
+				t.Errorf("unexpected create body: %v", body)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"id": "vr_new", "reference_id": body["reference_id"]})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	ctx := context.Background()
+
+	resource, created, err := client.VerificationRequests.EnsureByReference(ctx, "order_1", map[string]any{"channel": "email"})
+	if err != nil || created || resource["id"] != "vr_existing" {
+		t.Errorf("want existing request, got %v, %t, %v", resource, created, err)
+	}
+	if creates.Load() != 0 {
+		t.Errorf("existing reference should not create, got %d creates", creates.Load())
+	}
+
+	resource, created, err = client.VerificationRequests.EnsureByReference(ctx, "order_2", map[string]any{"channel": "email"})
+	if err != nil || !created || resource["id"] != "vr_new" {
+		t.Errorf("want created request, got %v, %t, %v", resource, created, err)
+	}
+}
+
+func TestVerificationRequests_EnsureByReferenceRejectsCRLF(t *testing.T) {
+	var creates atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			creates.Add(1)
+		}
+		w.WriteHeader(404)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, _, err := client.VerificationRequests.EnsureByReference(context.Background(), "order_1\r\nX-Injected: 1", nil)
+	if err == nil || !strings.Contains(err.Error(), "Idempotency-Key") {
+		t.Fatalf("want Idempotency-Key rejected, got %v", err)
+	}
+	if creates.Load() != 0 {
+		t.Errorf("want no create sent, got %d", creates.Load())
+	}
+}
+
+func TestVerificationRequestParams_Build(t *testing.T) {
+	params := &VerificationRequestParams{
+		Assets: []AssetRequirement{
+			{Type: AssetPhone, Required: true},
+			{Type: AssetEmail, Identifier: "user@example.com"},
+		},
+		ReferenceID: "user_123",
+		ExpiresIn:   24 * time.Hour,
+	}
+	got, err := params.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"assets": []map[string]any{
+			{"type": "phone", "required": true},
+			{"type": "email", "required": false, "identifier": "user@example.com"},
+		},
+		"reference_id": "user_123",
+		"expires_in":   86400,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() = %v, want %v", got, want)
+	}
+}
+
+func TestVerificationRequestParams_BuildRejectsInvalid(t *testing.T) {
+	for name, params := range map[string]*VerificationRequestParams{
+		"no assets":    {ReferenceID: "user_123"},
+		"unknown type": {Assets: []AssetRequirement{{Type: AssetPhone}, {Type: "passport"}}},
+	} {
+		_, err := params.Build()
+		var vErr *ValidationError
+		if !errors.As(err, &vErr) {
+			t.Errorf("%s: want ValidationError, got %T: %v", name, err, err)
+		}
+	}
+}
@@ -2,12 +2,17 @@ package proof
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // Verifications provides access to the verifications API.
 type Verifications struct {
-	http *httpClient
+	http   *httpClient
+	proofs *Proofs
 }
 
 // Create creates a new verification.
@@ -17,56 +22,223 @@ func (v *Verifications) Create(ctx context.Context, params map[string]any) (map[
 
 // Retrieve gets a verification by ID.
 func (v *Verifications) Retrieve(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.get(ctx, "/api/v1/verifications/"+url.PathEscape(id), nil)
 }
 
+// Update merges params into a pending verification and returns the updated
+// resource. Only metadata is mutable: reference_id and the metadata object;
+// fields not present in params are left unchanged.
+func (v *Verifications) Update(ctx context.Context, id string, params map[string]any) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
+	return v.http.patch(ctx, "/api/v1/verifications/"+url.PathEscape(id), params)
+}
+
 // List lists verifications with optional filters.
 func (v *Verifications) List(ctx context.Context, params map[string]string) (map[string]any, error) {
-	q := url.Values{}
-	for k, val := range params {
-		if val != "" {
-			q.Set(k, val)
-		}
+	return v.http.get(ctx, "/api/v1/verifications", queryFromMap(params))
+}
+
+// verificationSortColumns are the columns ListWithOptions can sort by.
+var verificationSortColumns = []string{"created_at", "updated_at", "status"}
+
+// ListWithOptions lists verifications with typed filters, sortable by
+// created_at, updated_at or status.
+func (v *Verifications) ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error) {
+	q, err := opts.query(verificationSortColumns...)
+	if err != nil {
+		return nil, err
 	}
 	return v.http.get(ctx, "/api/v1/verifications", q)
 }
 
+// ListByExternalUser lists verifications for one external user ID. Extra
+// filters are merged in; externalUserID always takes precedence and must not
+// be empty.
+func (v *Verifications) ListByExternalUser(ctx context.Context, externalUserID string, extra map[string]string) (map[string]any, error) {
+	if err := requireFilter("external user ID", externalUserID); err != nil {
+		return nil, err
+	}
+	params := make(map[string]string, len(extra)+1)
+	for k, val := range extra {
+		params[k] = val
+	}
+	params["external_user_id"] = externalUserID
+	return v.List(ctx, params)
+}
+
 // Verify triggers a DNS/HTTP verification check.
 func (v *Verifications) Verify(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.post(ctx, "/api/v1/verifications/"+url.PathEscape(id)+"/verify", nil)
 }
 
-// Submit submits an OTP/challenge code.
+// Submit submits an OTP/challenge code. A wrong code yields an
+// *IncorrectCodeError and a lockout a *RateLimitError; use RemainingAttempts
+// to read how many tries are left from either.
 func (v *Verifications) Submit(ctx context.Context, id, code string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.post(ctx, "/api/v1/verifications/"+url.PathEscape(id)+"/submit", map[string]string{"code": code})
 }
 
 // Resend resends a verification email (email channel only).
 func (v *Verifications) Resend(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.post(ctx, "/api/v1/verifications/"+url.PathEscape(id)+"/resend", nil)
 }
 
+// ResendResult is the typed outcome of Verifications.ResendTyped.
+type ResendResult struct {
+	// ResendsRemaining is how many more resends are allowed.
+	ResendsRemaining int
+	// NextResendAt is the earliest time another resend is permitted; zero if
+	// the server did not report a cooldown.
+	NextResendAt time.Time
+	// Resource is the full response.
+	Resource map[string]any
+}
+
+// ResendTyped resends a verification email like Resend and decodes the
+// resend allowance. When no resends are left the API responds with 429,
+// returned as a *RateLimitError whose RemainingAttempts and RetryAfter
+// describe the throttle, e.g. to disable a "Resend" button for RetryAfter
+// seconds.
+func (v *Verifications) ResendTyped(ctx context.Context, id string) (*ResendResult, error) {
+	resource, err := v.Resend(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	remaining, _ := GetInt(resource, "resends_remaining")
+	nextAt, _ := GetTime(resource, "next_resend_at")
+	return &ResendResult{ResendsRemaining: remaining, NextResendAt: nextAt, Resource: resource}, nil
+}
+
+// DeliveryAttempt is one attempt to deliver a verification's code or link to
+// the user, as returned by Verifications.ListDeliveries.
+type DeliveryAttempt struct {
+	ID       string `json:"id"`
+	Channel  string `json:"channel"`
+	Provider string `json:"provider"`
+	Status   string `json:"status"`
+	// Error is the provider's failure reason; empty unless the attempt failed.
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListDeliveries lists the delivery attempts (SMS, email, ...) made for a
+// verification, e.g. to find out why a code never arrived. These are unrelated
+// to webhook deliveries. Both a bare array and a {"data": [...]} envelope are
+// accepted.
+func (v *Verifications) ListDeliveries(ctx context.Context, id string) ([]DeliveryAttempt, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
+	path := "/api/v1/verifications/" + url.PathEscape(id) + "/deliveries"
+	attempts := []DeliveryAttempt{}
+	err := v.http.getStream(ctx, path, nil, func(item json.RawMessage) error {
+		var attempt DeliveryAttempt
+		if err := json.Unmarshal(item, &attempt); err != nil {
+			return err
+		}
+		attempts = append(attempts, attempt)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
 // TestVerify auto-completes a verification in test mode (pk_test_* API keys only).
 func (v *Verifications) TestVerify(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.post(ctx, "/api/v1/verifications/"+url.PathEscape(id)+"/test-verify", nil)
 }
 
 // ListVerifiedUsers lists verified users grouped by external_user_id.
 func (v *Verifications) ListVerifiedUsers(ctx context.Context, params map[string]string) (map[string]any, error) {
-	q := url.Values{}
-	for k, val := range params {
-		if val != "" {
-			q.Set(k, val)
-		}
-	}
-	return v.http.get(ctx, "/api/v1/verifications/users", q)
+	return v.http.get(ctx, "/api/v1/verifications/users", queryFromMap(params))
 }
 
 // GetVerifiedUser gets a single verified user's verifications by external user ID.
 func (v *Verifications) GetVerifiedUser(ctx context.Context, externalUserID string) (map[string]any, error) {
+	if err := requireSegment("external user ID", externalUserID); err != nil {
+		return nil, err
+	}
 	return v.http.get(ctx, "/api/v1/verifications/users/"+url.PathEscape(externalUserID), nil)
 }
 
+// VerifiedUser is one entry of the verified users listing.
+type VerifiedUser struct {
+	ExternalUserID string           `json:"external_user_id"`
+	Verifications  []map[string]any `json:"verifications"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// verifiedUsersPageSize is the page size ListVerifiedUsersSince requests.
+const verifiedUsersPageSize = 100
+
+// ListVerifiedUsersSince returns every verified user changed after since,
+// fetching all pages, for incremental syncs. Users whose updated_at is not
+// after since are dropped even if the server returns them, so a server that
+// ignores the updated_after filter still yields only changes. Paging stops at
+// the first page with no user not already seen, so a server that ignores
+// offset or never clears has_more cannot loop forever.
+func (v *Verifications) ListVerifiedUsersSince(ctx context.Context, since time.Time) ([]VerifiedUser, error) {
+	users := []VerifiedUser{}
+	seen := map[string]bool{}
+	for offset := 0; ; {
+		q := url.Values{}
+		q.Set("updated_after", since.UTC().Format(time.RFC3339))
+		q.Set("limit", strconv.Itoa(verifiedUsersPageSize))
+		q.Set("offset", strconv.Itoa(offset))
+		var pageLen, pageNew int
+		var pagination struct {
+			HasMore bool `json:"has_more"`
+		}
+		err := v.http.getStream(ctx, "/api/v1/verifications/users", q, func(item json.RawMessage) error {
+			var user VerifiedUser
+			if err := json.Unmarshal(item, &user); err != nil {
+				return err
+			}
+			pageLen++
+			if seen[user.ExternalUserID] {
+				return nil
+			}
+			seen[user.ExternalUserID] = true
+			pageNew++
+			if user.UpdatedAt.IsZero() || user.UpdatedAt.After(since) {
+				users = append(users, user)
+			}
+			return nil
+		}, func(key string, value json.RawMessage) error {
+			if key != "pagination" {
+				return nil
+			}
+			return json.Unmarshal(value, &pagination)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !pagination.HasMore || pageNew == 0 {
+			return users, nil
+		}
+		offset += pageLen
+	}
+}
+
 // StartDomainVerification starts a B2B domain verification.
 func (v *Verifications) StartDomainVerification(ctx context.Context, params map[string]any) (map[string]any, error) {
 	return v.http.post(ctx, "/api/v1/verifications/domain", params)
@@ -74,20 +246,198 @@ func (v *Verifications) StartDomainVerification(ctx context.Context, params map[
 
 // CheckDomainVerification checks a pending domain verification (DNS/HTTP file).
 func (v *Verifications) CheckDomainVerification(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return v.http.post(ctx, "/api/v1/verifications/domain/"+url.PathEscape(id)+"/check", nil)
 }
 
-// WaitForCompletion polls until verification reaches a terminal state.
+// WaitForCompletion polls until verification reaches a terminal state. With
+// opts.PreferStream it follows the status stream instead, falling back to
+// polling if the stream is unavailable or drops.
 func (v *Verifications) WaitForCompletion(ctx context.Context, id string, opts *WaitOptions) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
+	return waitWithStream(
+		ctx,
+		v.http,
+		"/api/v1/verifications/"+url.PathEscape(id)+"/stream",
+		func(c context.Context) (map[string]any, error) { return v.Retrieve(c, id) },
+		isTerminalVerificationStatus,
+		"Verification "+id,
+		withWaitDefaults(opts, v.http.waitDefaults),
+	)
+}
+
+// WaitForCompletionOpts is WaitForCompletion configured with WaitOption
+// functions instead of a *WaitOptions.
+func (v *Verifications) WaitForCompletionOpts(ctx context.Context, id string, opts ...WaitOption) (map[string]any, error) {
+	return v.WaitForCompletion(ctx, id, buildWaitOptions(opts))
+}
+
+// Watch polls a verification like WaitForCompletion and calls cb whenever its
+// status changes, starting with the first status seen; repeated polls with
+// the same status do not call cb again. It returns the final resource once
+// the status is terminal, or an error when ctx is done or the wait times out.
+// Watch always polls; opts.PreferStream is ignored.
+func (v *Verifications) Watch(ctx context.Context, id string, cb func(status string, resource map[string]any), opts *WaitOptions) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
+	var watchOpts WaitOptions
+	if merged := withWaitDefaults(opts, v.http.waitDefaults); merged != nil {
+		watchOpts = *merged
+	}
+	onPoll := watchOpts.OnPoll
+	var last string
+	watchOpts.OnPoll = func(poll int, resource map[string]any) {
+		if onPoll != nil {
+			onPoll(poll, resource)
+		}
+		status, _ := resource["status"].(string)
+		if poll == 0 || status != last {
+			last = status
+			cb(status, resource)
+		}
+	}
 	return pollUntilComplete(
 		ctx,
+		v.http.clock,
 		func(c context.Context) (map[string]any, error) { return v.Retrieve(c, id) },
 		isTerminalVerificationStatus,
 		"Verification "+id,
-		opts,
+		&watchOpts,
+	)
+}
+
+// ProofUnavailableError is returned by Verifications.WaitForProof when the
+// verification completed without a proof: Code is "verification_not_verified"
+// if it ended in another status, or "missing_proof" if it is verified but the
+// response carries no token.
+type ProofUnavailableError struct {
+	ProofError
+	VerificationID string
+	// Status is the verification's final status.
+	Status string
+}
+
+// WaitForProof waits like WaitForCompletion and returns the proof token of the
+// verified verification along with the final resource. It fails with a
+// *ProofUnavailableError if the verification ends in any status other than
+// "verified" or carries no token (under "proof_token" or "proof.token"); the
+// resource is still returned then.
+func (v *Verifications) WaitForProof(ctx context.Context, id string, opts *WaitOptions) (string, map[string]any, error) {
+	resource, err := v.WaitForCompletion(ctx, id, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	status, _ := GetString(resource, "status")
+	if VerificationStatus(status) != VerificationStatusVerified {
+		return "", resource, &ProofUnavailableError{
+			ProofError: ProofError{
+				Message: fmt.Sprintf("verification %s ended with status %q, not %q", id, status, VerificationStatusVerified),
+				Code:    "verification_not_verified",
+			},
+			VerificationID: id,
+			Status:         status,
+		}
+	}
+	token, ok := GetString(resource, "proof_token")
+	if !ok || token == "" {
+		token, ok = GetString(resource, "proof", "token")
+	}
+	if !ok || token == "" {
+		return "", resource, &ProofUnavailableError{
+			ProofError: ProofError{
+				Message: fmt.Sprintf("verification %s is verified but the response has no proof token", id),
+				Code:    "missing_proof",
+			},
+			VerificationID: id,
+			Status:         status,
+		}
+	}
+	return token, resource, nil
+}
+
+// VerifiedProof is the result of Verifications.WaitForVerifiedProof.
+type VerifiedProof struct {
+	Token string
+	// Claims have been checked with Proofs.VerifyOffline.
+	Claims   ProofClaims
+	Resource map[string]any
+}
+
+// WaitForVerifiedProof is WaitForProof followed by Proofs.VerifyOffline on the
+// token, for callers that want to trust the result without a separate step. It
+// is opt-in because it fetches the JWKS signing keys on first use; like
+// VerifyOffline it does not check revocation. The token is bound to a
+// verification by its verification_id claim, or by sub when it has no
+// verification_id; a valid token bound to anything but id fails with an
+// *IdentifierMismatchError.
+func (v *Verifications) WaitForVerifiedProof(ctx context.Context, id string, opts *WaitOptions) (*VerifiedProof, error) {
+	token, resource, err := v.WaitForProof(ctx, id, opts)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := v.proofs.VerifyOffline(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	bound := claims.VerificationID
+	if bound == "" {
+		bound = claims.Subject
+	}
+	if bound != id {
+		return nil, &IdentifierMismatchError{
+			ProofError: ProofError{
+				Message: fmt.Sprintf("proof token was issued for verification %q, not %q", bound, id),
+				Code:    "verification_mismatch",
+			},
+			Expected: id,
+			Actual:   bound,
+		}
+	}
+	return &VerifiedProof{Token: token, Claims: claims, Resource: resource}, nil
+}
+
+// Stream emits the verification's status changes as they happen using the
+// Server-Sent Events endpoint, falling back to polling when the server does not
+// offer a stream. The channel is closed after a terminal status, after an event
+// carrying Err, or when ctx is done; cancel ctx to stop early.
+func (v *Verifications) Stream(ctx context.Context, id string) (<-chan StatusEvent, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
+	return streamStatus(
+		ctx,
+		v.http,
+		"/api/v1/verifications/"+url.PathEscape(id)+"/stream",
+		func(c context.Context) (map[string]any, error) { return v.Retrieve(c, id) },
+		isTerminalVerificationStatus,
 	)
 }
 
+// VerificationStatus is the "status" of a verification.
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusVerified VerificationStatus = "verified"
+	VerificationStatusFailed   VerificationStatus = "failed"
+	VerificationStatusExpired  VerificationStatus = "expired"
+	VerificationStatusRevoked  VerificationStatus = "revoked"
+)
+
+// IsTerminal reports whether the verification can no longer change status.
+func (s VerificationStatus) IsTerminal() bool {
+	switch s {
+	case VerificationStatusVerified, VerificationStatusFailed, VerificationStatusExpired, VerificationStatusRevoked:
+		return true
+	}
+	return false
+}
+
 func isTerminalVerificationStatus(s string) bool {
-	return s == "verified" || s == "failed" || s == "expired" || s == "revoked"
+	return VerificationStatus(s).IsTerminal()
 }
@@ -0,0 +1,379 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifications_SubmitIncorrectCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "incorrect_code", "message": "Incorrect code", "remaining_attempts": 2},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.Submit(context.Background(), "ver_1", "000000")
+
+	var codeErr *IncorrectCodeError
+	if !errors.As(err, &codeErr) {
+		t.Fatalf("want IncorrectCodeError, got %T: %v", err, err)
+	}
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Error("IncorrectCodeError should unwrap to ValidationError")
+	}
+	if n, ok := RemainingAttempts(err); !ok || n != 2 {
+		t.Errorf("want 2 remaining attempts, got %d (ok=%v)", n, ok)
+	}
+}
+
+func TestVerifications_SubmitLockout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "too_many_attempts", "message": "Locked", "remaining_attempts": 0, "retryAfter": 900},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.Submit(context.Background(), "ver_1", "000000")
+
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("want RateLimitError, got %T: %v", err, err)
+	}
+	if n, ok := RemainingAttempts(err); !ok || n != 0 {
+		t.Errorf("want 0 remaining attempts, got %d (ok=%v)", n, ok)
+	}
+}
+
+func TestRemainingAttempts_NoInfo(t *testing.T) {
+	if _, ok := RemainingAttempts(&ValidationError{ProofError{Code: "invalid"}}); ok {
+		t.Error("want ok=false for error without attempt info")
+	}
+	if _, ok := RemainingAttempts(nil); ok {
+		t.Error("want ok=false for nil error")
+	}
+}
+
+func TestVerifications_ListByExternalUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/verifications" {
+			t.Errorf("want GET /api/v1/verifications, got %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("external_user_id") != "user_42" {
+			t.Errorf("want external_user_id 'user_42', got %q", q.Get("external_user_id"))
+		}
+		if q.Get("status") != "verified" {
+			t.Errorf("want status 'verified', got %q", q.Get("status"))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, err := client.Verifications.ListByExternalUser(context.Background(), "user_42", map[string]string{
+		"status":           "verified",
+		"external_user_id": "someone_else",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifications_ListByExternalUserEmptyID(t *testing.T) {
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	_, err := client.Verifications.ListByExternalUser(context.Background(), "", map[string]string{"status": "verified"})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Code != "missing_filter" {
+		t.Fatalf("want missing_filter ValidationError, got %T: %v", err, err)
+	}
+	if callCount.Load() != 0 {
+		t.Errorf("want no request for an empty external user ID, got %d", callCount.Load())
+	}
+}
+
+func TestVerifications_ResendTyped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/verifications/ver_1/resend" {
+			t.Errorf("want POST /api/v1/verifications/ver_1/resend, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":                "ver_1",
+			"resends_remaining": 2,
+			"next_resend_at":    "2026-10-15T12:01:00Z",
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	result, err := client.Verifications.ResendTyped(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ResendsRemaining != 2 {
+		t.Errorf("want 2 resends remaining, got %d", result.ResendsRemaining)
+	}
+	if want := time.Date(2026, 10, 15, 12, 1, 0, 0, time.UTC); !result.NextResendAt.Equal(want) {
+		t.Errorf("want NextResendAt %v, got %v", want, result.NextResendAt)
+	}
+}
+
+func TestVerifications_Update(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/v1/verifications/ver_1" {
+			t.Errorf("want PATCH /api/v1/verifications/ver_1, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["reference_id"] != "order_42" {
+			t.Errorf("want reference_id order_42, got %v", body["reference_id"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": "pending", "reference_id": "order_42"})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	result, err := client.Verifications.Update(context.Background(), "ver_1", map[string]any{"reference_id": "order_42"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["reference_id"] != "order_42" {
+		t.Errorf("want updated resource, got %v", result)
+	}
+}
+
+func TestVerifications_ListDeliveries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/verifications/ver_1/deliveries" {
+			t.Errorf("want GET /api/v1/verifications/ver_1/deliveries, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{
+			{"id": "dlv_1", "channel": "sms", "provider": "twilio", "status": "failed", "error": "unreachable handset", "created_at": "2026-10-15T12:00:00Z"},
+			{"id": "dlv_2", "channel": "sms", "provider": "vonage", "status": "delivered"},
+		}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	attempts, err := client.Verifications.ListDeliveries(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("want 2 attempts, got %d", len(attempts))
+	}
+	first := attempts[0]
+	if first.Channel != "sms" || first.Provider != "twilio" || first.Status != "failed" || first.Error != "unreachable handset" {
+		t.Errorf("unexpected first attempt: %+v", first)
+	}
+	if want := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC); !first.CreatedAt.Equal(want) {
+		t.Errorf("want CreatedAt %v, got %v", want, first.CreatedAt)
+	}
+	if attempts[1].Error != "" {
+		t.Errorf("successful attempt should have no error, got %q", attempts[1].Error)
+	}
+}
+
+func TestVerifications_ResendTypedExhausted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "resend_limit_reached", "message": "No resends left", "remaining_attempts": 0, "retryAfter": 600},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.ResendTyped(context.Background(), "ver_1")
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("want RateLimitError, got %T: %v", err, err)
+	}
+	if n, ok := RemainingAttempts(err); !ok || n != 0 {
+		t.Errorf("want 0 remaining, got %d (ok=%v)", n, ok)
+	}
+	if rlErr.RetryAfter == nil || *rlErr.RetryAfter != 600 {
+		t.Errorf("want RetryAfter 600, got %v", rlErr.RetryAfter)
+	}
+}
+
+func TestVerifications_ListVerifiedUsersSince(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/verifications/users" {
+			t.Errorf("want /api/v1/verifications/users, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("updated_after") != "2026-10-01T00:00:00Z" {
+			t.Errorf("want updated_after in RFC3339, got %q", q.Get("updated_after"))
+		}
+		offsets = append(offsets, q.Get("offset"))
+		if q.Get("offset") == "0" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{
+					{"external_user_id": "u1", "updated_at": "2026-10-02T00:00:00Z"},
+					// Unchanged since the last sync; the server ignored the filter.
+					{"external_user_id": "u0", "updated_at": "2026-09-01T00:00:00Z"},
+				},
+				"pagination": map[string]any{"has_more": true},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"external_user_id": "u2", "updated_at": "2026-10-03T00:00:00Z", "verifications": []map[string]any{{"id": "ver_2"}}},
+			},
+			"pagination": map[string]any{"has_more": false},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	users, err := client.Verifications.ListVerifiedUsersSince(context.Background(), since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(offsets) != 2 || offsets[1] != "2" {
+		t.Errorf("want two pages at offsets 0 and 2, got %v", offsets)
+	}
+	if len(users) != 2 || users[0].ExternalUserID != "u1" || users[1].ExternalUserID != "u2" {
+		t.Fatalf("want users u1 and u2, got %+v", users)
+	}
+	if len(users[1].Verifications) != 1 || !users[1].UpdatedAt.Equal(time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected decode: %+v", users[1])
+	}
+}
+
+func TestVerifications_ListVerifiedUsersSinceServerIgnoresOffset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"external_user_id": "u1", "updated_at": "2026-10-02T00:00:00Z"},
+				{"external_user_id": "u2", "updated_at": "2026-10-03T00:00:00Z"},
+			},
+			"pagination": map[string]any{"has_more": true},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	users, err := client.Verifications.ListVerifiedUsersSince(context.Background(), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 || calls.Load() != 2 {
+		t.Errorf("want 2 users after 2 pages, got %d users after %d pages", len(users), calls.Load())
+	}
+}
+
+func TestVerifications_WaitForProof(t *testing.T) {
+	responses := map[string]map[string]any{
+		"ver_token":    {"id": "ver_token", "status": "verified", "proof_token": "eyJ.token.sig"},
+		"ver_nested":   {"id": "ver_nested", "status": "verified", "proof": map[string]any{"token": "eyJ.nested.sig"}},
+		"ver_no_token": {"id": "ver_no_token", "status": "verified"},
+		"ver_failed":   {"id": "ver_failed", "status": "failed"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responses[strings.TrimPrefix(r.URL.Path, "/api/v1/verifications/")])
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	for id, want := range map[string]string{"ver_token": "eyJ.token.sig", "ver_nested": "eyJ.nested.sig"} {
+		token, resource, err := client.Verifications.WaitForProof(ctx, id, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if token != want || resource["id"] != id {
+			t.Errorf("%s: want token %q, got %q", id, want, token)
+		}
+	}
+
+	_, resource, err := client.Verifications.WaitForProof(ctx, "ver_no_token", nil)
+	var unavailable *ProofUnavailableError
+	if !errors.As(err, &unavailable) || unavailable.Code != "missing_proof" || unavailable.VerificationID != "ver_no_token" {
+		t.Errorf("want missing_proof ProofUnavailableError, got %T: %v", err, err)
+	}
+	if resource == nil {
+		t.Error("resource should be returned with the missing token error")
+	}
+
+	_, _, err = client.Verifications.WaitForProof(ctx, "ver_failed", nil)
+	if !errors.As(err, &unavailable) || unavailable.Code != "verification_not_verified" || unavailable.Status != "failed" {
+		t.Errorf("want verification_not_verified ProofUnavailableError, got %T: %v", err, err)
+	}
+}
+
+func TestVerifications_ResendTypedThrottledHeaderOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "45")
+		w.WriteHeader(429)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "resend_cooldown", "message": "Too soon"}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	_, err := client.Verifications.ResendTyped(context.Background(), "ver_1")
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("want RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.RetryAfter == nil || *rlErr.RetryAfter != 45 {
+		t.Errorf("want RetryAfter 45 from the header, got %v", rlErr.RetryAfter)
+	}
+}
+
+func TestVerifications_Watch(t *testing.T) {
+	statuses := []string{"pending", "pending", "pending", "verified"}
+	var callCount atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(callCount.Add(1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "ver_1", "status": statuses[n]})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL), WithMaxRetries(0))
+	var seen []string
+	result, err := client.Verifications.Watch(context.Background(), "ver_1", func(status string, resource map[string]any) {
+		seen = append(seen, status)
+	}, &WaitOptions{Interval: time.Millisecond, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "verified" {
+		t.Errorf("want final status verified, got %v", result["status"])
+	}
+	if strings.Join(seen, ",") != "pending,verified" {
+		t.Errorf("want cb on status changes only, got %v", seen)
+	}
+	if callCount.Load() != 4 {
+		t.Errorf("want 4 polls, got %d", callCount.Load())
+	}
+}
@@ -2,7 +2,11 @@ package proof
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 )
 
 // WebhookDeliveries provides access to the webhook deliveries API.
@@ -15,23 +19,128 @@ func (w *WebhookDeliveries) Stats(ctx context.Context) (map[string]any, error) {
 	return w.http.get(ctx, "/api/v1/webhook-deliveries/stats", nil)
 }
 
-// List lists webhook deliveries with optional filters.
-func (w *WebhookDeliveries) List(ctx context.Context, params map[string]string) (map[string]any, error) {
+// StatsParams filters WebhookDeliveries.StatsTyped. Zero values are omitted.
+type StatsParams struct {
+	From time.Time
+	To   time.Time
+	// GroupBy buckets the range by "hour" or "day".
+	GroupBy string
+}
+
+// WebhookStats is the typed result of WebhookDeliveries.StatsTyped.
+type WebhookStats struct {
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	ByStatus  map[string]int `json:"by_status"`
+	// Buckets is only populated when StatsParams.GroupBy is set.
+	Buckets []WebhookStatsBucket `json:"buckets"`
+}
+
+// WebhookStatsBucket holds the counts for one GroupBy interval.
+type WebhookStatsBucket struct {
+	Start     time.Time `json:"start"`
+	Total     int       `json:"total"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+}
+
+// StatsTyped gets webhook delivery statistics for a time range, optionally
+// grouped into buckets, decoded into a WebhookStats. params may be nil for
+// all-time totals.
+func (w *WebhookDeliveries) StatsTyped(ctx context.Context, params *StatsParams) (*WebhookStats, error) {
 	q := url.Values{}
-	for k, val := range params {
-		if val != "" {
-			q.Set(k, val)
+	if params != nil {
+		if !params.From.IsZero() {
+			q.Set("from", params.From.UTC().Format(time.RFC3339))
 		}
+		if !params.To.IsZero() {
+			q.Set("to", params.To.UTC().Format(time.RFC3339))
+		}
+		if params.GroupBy != "" {
+			q.Set("group_by", params.GroupBy)
+		}
+	}
+	raw, err := w.http.requestRawJSON(ctx, http.MethodGet, "/api/v1/webhook-deliveries/stats", nil, q)
+	if err != nil {
+		return nil, err
+	}
+	stats := &WebhookStats{}
+	if err := json.Unmarshal(raw, stats); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook delivery stats: %w", err)
+	}
+	return stats, nil
+}
+
+// List lists webhook deliveries with optional filters.
+func (w *WebhookDeliveries) List(ctx context.Context, params map[string]string) (map[string]any, error) {
+	return w.http.get(ctx, "/api/v1/webhook-deliveries", queryFromMap(params))
+}
+
+// webhookDeliverySortColumns are the columns ListWithOptions can sort by.
+var webhookDeliverySortColumns = []string{"created_at", "status"}
+
+// ListWithOptions lists webhook deliveries with typed filters, sortable by
+// created_at or status.
+func (w *WebhookDeliveries) ListWithOptions(ctx context.Context, opts *ListOptions) (map[string]any, error) {
+	q, err := opts.query(webhookDeliverySortColumns...)
+	if err != nil {
+		return nil, err
 	}
 	return w.http.get(ctx, "/api/v1/webhook-deliveries", q)
 }
 
+// WebhookDelivery is one webhook delivery, as returned by ListByVerification.
+type WebhookDelivery struct {
+	ID             string    `json:"id"`
+	VerificationID string    `json:"verification_id"`
+	Event          string    `json:"event"`
+	URL            string    `json:"url"`
+	Status         string    `json:"status"`
+	ResponseStatus int       `json:"response_status"`
+	Attempts       int       `json:"attempts"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ListByVerification lists the webhook deliveries for one verification, e.g.
+// to confirm its completion event reached your endpoint.
+func (w *WebhookDeliveries) ListByVerification(ctx context.Context, verificationID string) ([]WebhookDelivery, error) {
+	if err := requireFilter("verification ID", verificationID); err != nil {
+		return nil, err
+	}
+	resource, err := w.List(ctx, map[string]string{"verification_id": verificationID})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := []WebhookDelivery{}
+	if data, ok := resource["data"]; ok {
+		raw, _ := json.Marshal(data)
+		if err := json.Unmarshal(raw, &deliveries); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook deliveries: %w", err)
+		}
+	}
+	return deliveries, nil
+}
+
 // Retrieve gets a webhook delivery by ID.
 func (w *WebhookDeliveries) Retrieve(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return w.http.get(ctx, "/api/v1/webhook-deliveries/"+url.PathEscape(id), nil)
 }
 
 // Retry retries a failed webhook delivery.
 func (w *WebhookDeliveries) Retry(ctx context.Context, id string) (map[string]any, error) {
+	if err := requireSegment("id", id); err != nil {
+		return nil, err
+	}
 	return w.http.post(ctx, "/api/v1/webhook-deliveries/"+url.PathEscape(id)+"/retry", nil)
 }
+
+// SendTest asks the server to deliver a test event to a webhook endpoint (for
+// example {"url": "https://yourapp.com/webhook"}) and returns the delivery
+// result, so you can confirm the endpoint responds before relying on it.
+func (w *WebhookDeliveries) SendTest(ctx context.Context, params map[string]any) (map[string]any, error) {
+	return w.http.post(ctx, "/api/v1/webhook-deliveries/test", params)
+}
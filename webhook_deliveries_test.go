@@ -0,0 +1,109 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveries_SendTest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/webhook-deliveries/test" {
+			t.Errorf("want POST /api/v1/webhook-deliveries/test, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["url"] != "https://example.com/webhook" {
+			t.Errorf("want url in body, got %v", body)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "del_test", "status": "delivered", "response_status": 200})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	result, err := client.WebhookDeliveries.SendTest(context.Background(), map[string]any{"url": "https://example.com/webhook"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "delivered" {
+		t.Errorf("want status 'delivered', got %v", result["status"])
+	}
+}
+
+func TestWebhookDeliveries_StatsTyped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/webhook-deliveries/stats" {
+			t.Errorf("want GET /api/v1/webhook-deliveries/stats, got %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("from") != "2026-10-01T00:00:00Z" || q.Get("to") != "2026-10-02T00:00:00Z" || q.Get("group_by") != "hour" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"total": 10, "succeeded": 8, "failed": 2,
+			"by_status": map[string]int{"delivered": 8, "failed": 2},
+			"buckets": []map[string]any{
+				{"start": "2026-10-01T00:00:00Z", "total": 10, "succeeded": 8, "failed": 2},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	from := time.Date(2026, 10, 1, 2, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	stats, err := client.WebhookDeliveries.StatsTyped(context.Background(), &StatsParams{
+		From:    from,
+		To:      from.Add(24 * time.Hour),
+		GroupBy: "hour",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 10 || stats.Succeeded != 8 || stats.Failed != 2 || stats.ByStatus["failed"] != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Buckets) != 1 || stats.Buckets[0].Total != 10 {
+		t.Errorf("unexpected buckets: %+v", stats.Buckets)
+	}
+}
+
+func TestWebhookDeliveries_ListByVerification(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/webhook-deliveries" {
+			t.Errorf("want GET /api/v1/webhook-deliveries, got %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("verification_id"); got != "ver_1" {
+			t.Errorf("want verification_id=ver_1, got %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{
+			{"id": "del_1", "verification_id": "ver_1", "event": "verification.verified", "status": "delivered", "response_status": 200, "attempts": 1},
+		}})
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient("pk_test_123", WithBaseURL(srv.URL))
+	deliveries, err := client.WebhookDeliveries.ListByVerification(context.Background(), "ver_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("want 1 delivery, got %d", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.ID != "del_1" || d.Event != "verification.verified" || d.Status != "delivered" || d.ResponseStatus != 200 {
+		t.Errorf("unexpected delivery: %+v", d)
+	}
+}
+
+func TestWebhookDeliveries_ListByVerificationEmptyID(t *testing.T) {
+	client, _ := NewClient("pk_test_123", WithBaseURL("http://127.0.0.1:1"))
+	_, err := client.WebhookDeliveries.ListByVerification(context.Background(), "")
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Code != "missing_filter" {
+		t.Fatalf("want missing_filter ValidationError before any request, got %T: %v", err, err)
+	}
+}